@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	utilpointer "k8s.io/utils/pointer"
@@ -31,25 +32,156 @@ func GetClusterName(obj Object) string {
 	return obj.GetAnnotations()[names.ClusterNameAnnotation]
 }
 
+// ApplyOutcome is the outcome of applying an object
+type ApplyOutcome string
+
+const (
+	// ApplyCreated means the object did not exist and was created
+	ApplyCreated ApplyOutcome = "created"
+	// ApplyUpdated means the existing object was changed
+	ApplyUpdated ApplyOutcome = "updated"
+	// ApplyUnchanged means the existing object already matched
+	ApplyUnchanged ApplyOutcome = "unchanged"
+	// ApplySkipped means the object was not applied, because of its create-wait or
+	// create-only annotation
+	ApplySkipped ApplyOutcome = "skipped"
+	// ApplyFailed means the object could not be applied
+	ApplyFailed ApplyOutcome = "error"
+
+	// applyApplied means the object was applied, without retrieving it first to tell whether
+	// it was created or changed
+	applyApplied ApplyOutcome = "applied"
+)
+
+// ApplyResult is the outcome of applying an object
+type ApplyResult struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+	Outcome   ApplyOutcome
+	// ResourceVersion is the resourceVersion of the applied object
+	ResourceVersion string
+	// Err is set when the Outcome is ApplyFailed
+	Err error
+}
+
+func (r ApplyResult) String() string {
+	return fmt.Sprintf("(%s) %s/%s: %s", r.GVK.String(), r.Namespace, r.Name, r.Outcome)
+}
+
+// ApplyResults are the outcomes of applying a set of objects
+type ApplyResults []ApplyResult
+
+// Failed returns the results of the objects that could not be applied
+func (rs ApplyResults) Failed() ApplyResults {
+	var failed ApplyResults
+	for _, r := range rs {
+		if r.Outcome == ApplyFailed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Summary returns the number of objects per outcome, e.g. "3 created, 1 error"
+func (rs ApplyResults) Summary() string {
+	counts := map[ApplyOutcome]int{}
+	for _, r := range rs {
+		counts[r.Outcome]++
+	}
+	var summary []string
+	for _, outcome := range []ApplyOutcome{ApplyCreated, ApplyUpdated, ApplyUnchanged, ApplySkipped, ApplyFailed} {
+		if counts[outcome] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[outcome], outcome))
+		}
+	}
+	if len(summary) == 0 {
+		return "no objects"
+	}
+	return strings.Join(summary, ", ")
+}
+
 // ApplyObject submits a server-side apply patch for the given object.
 // This causes fields we own to be updated, and fields we don't own to be preserved.
 // For more information, see https://kubernetes.io/docs/reference/using-api/server-side-apply/
 // The subcontroller, if set, is used to assign field ownership.
 func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subcontroller string, subresources ...string) error {
+	_, _, err := applyObject(ctx, client, obj, subcontroller, false, subresources...)
+	return err
+}
+
+// applyObjectWithResult is applyObject, reporting the outcome of the object as an ApplyResult
+func applyObjectWithResult(ctx context.Context, client cnoclient.Client, obj Object, subcontroller string, withOutcome bool, subresources ...string) ApplyResult {
+	result := ApplyResult{
+		GVK:       obj.GetObjectKind().GroupVersionKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	outcome, resourceVersion, err := applyObject(ctx, client, obj, subcontroller, withOutcome, subresources...)
+	if err != nil {
+		result.Outcome = ApplyFailed
+		result.Err = err
+		return result
+	}
+	// the Kind is set from the scheme while applying
+	result.GVK = obj.GetObjectKind().GroupVersionKind()
+	result.Outcome = outcome
+	result.ResourceVersion = resourceVersion
+	return result
+}
+
+// ApplyTracker applies objects reporting whether they were created, updated or left unchanged,
+// while retrieving each object only the first time it applies it. After that, the resourceVersion
+// of an applied object is compared with the one of its previous apply, so an object that was
+// deleted and created again meanwhile is reported as updated. The zero value is ready to use.
+type ApplyTracker struct {
+	resourceVersions map[string]string
+}
+
+// ApplyObject is ApplyObject, also reporting the outcome. The object is only retrieved first the
+// first time the tracker applies it.
+func (t *ApplyTracker) ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subcontroller string, subresources ...string) ApplyResult {
+	key := fmt.Sprintf("%s/%s/%s/%s", GetClusterName(obj), obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	_, applied := t.resourceVersions[key]
+	return t.record(key, applyObjectWithResult(ctx, client, obj, subcontroller, !applied, subresources...))
+}
+
+// record resolves the outcome of an object applied without retrieving it first, comparing its
+// resourceVersion with the recorded one, and records the resourceVersion for the next apply
+func (t *ApplyTracker) record(key string, result ApplyResult) ApplyResult {
+	if t.resourceVersions == nil {
+		t.resourceVersions = map[string]string{}
+	}
+	if result.Outcome == applyApplied {
+		result.Outcome = ApplyUpdated
+		if result.ResourceVersion == t.resourceVersions[key] {
+			result.Outcome = ApplyUnchanged
+		}
+	}
+	if result.ResourceVersion != "" {
+		t.resourceVersions[key] = result.ResourceVersion
+	}
+	return result
+}
+
+// applyObject applies the object and returns the outcome and the resourceVersion of the applied
+// object. Unless withOutcome is set or the annotations require it, the object isn't retrieved first
+// and the outcome of a successful apply is applyApplied.
+func applyObject(ctx context.Context, client cnoclient.Client, obj Object, subcontroller string, withOutcome bool, subresources ...string) (ApplyOutcome, string, error) {
 	name := obj.GetName()
 	namespace := obj.GetNamespace()
 	clusterClient := client.ClientFor(GetClusterName(obj))
 	if clusterClient == nil {
-		return fmt.Errorf("object %s/%s specifies unknown cluster %s", namespace, name, GetClusterName(obj))
+		return ApplyFailed, "", fmt.Errorf("object %s/%s specifies unknown cluster %s", namespace, name, GetClusterName(obj))
 	}
 
 	oks, _, _ := clusterClient.Scheme().ObjectKinds(obj)
 	if len(oks) == 0 {
-		return errors.Errorf("Object %s/%s has no Kind registered in the Scheme", namespace, name)
+		return ApplyFailed, "", errors.Errorf("Object %s/%s has no Kind registered in the Scheme", namespace, name)
 	}
 	gvk := oks[0]
 	if name == "" {
-		return errors.Errorf("Object %s has no name", gvk)
+		return ApplyFailed, "", errors.Errorf("Object %s has no name", gvk)
 	}
 
 	// Dragons: If we're passed a non-Unstructured object (e.g. v1.ConfigMap), it won't have
@@ -67,31 +199,37 @@ func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subco
 		var err error
 		obj, err = getCopySource(ctx, obj, client)
 		if err != nil {
-			return fmt.Errorf("failed to retrieve copy-from object: %w", err)
+			return ApplyFailed, "", fmt.Errorf("failed to retrieve copy-from object: %w", err)
 		}
 	}
 
 	// determine resource
 	rm, err := clusterClient.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve resource from Object %s: %v", objDesc, err)
+		return ApplyFailed, "", fmt.Errorf("failed to retrieve resource from Object %s: %v", objDesc, err)
 	}
 
 	// If create-wait is specified, ignore creating the object
 	if _, ok := obj.GetAnnotations()[names.CreateWaitAnnotation]; ok {
 		log.Printf("Object %s has create-wait annotation, skipping apply.", objDesc)
-		return nil
+		return ApplySkipped, "", nil
 	}
 
-	// If create-only is specified, check to see if exists
-	if _, ok := obj.GetAnnotations()[names.CreateOnlyAnnotation]; ok {
-		_, err := clusterClient.Dynamic().Resource(rm.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err == nil {
-			log.Printf("Object %s has create-only annotation and already exists, skipping apply.", objDesc)
-			return nil
+	// If create-only or replace-on-change is specified, or the outcome is requested, check to see if exists
+	_, createOnly := obj.GetAnnotations()[names.CreateOnlyAnnotation]
+	_, replaceOnChange := obj.GetAnnotations()[names.ReplaceOnChangeAnnotation]
+	var existing *unstructured.Unstructured
+	retrieved := createOnly || replaceOnChange || withOutcome
+	if retrieved {
+		existing, err = clusterClient.Dynamic().Resource(rm.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			existing = nil
+		} else if err != nil {
+			return ApplyFailed, "", err
 		}
-		if !apierrors.IsNotFound(err) {
-			return err
+		if createOnly && existing != nil {
+			log.Printf("Object %s has create-only annotation and already exists, skipping apply.", objDesc)
+			return ApplySkipped, "", nil
 		}
 	}
 
@@ -102,7 +240,7 @@ func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subco
 		// apply is not doing what we want
 		obj, err = merge(ctx, clusterClient)
 		if err != nil {
-			return fmt.Errorf("failed to merge object %s: %w", objDesc, err)
+			return ApplyFailed, "", fmt.Errorf("failed to merge object %s: %w", objDesc, err)
 		}
 	}
 
@@ -113,6 +251,23 @@ func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subco
 		fieldManager = fmt.Sprintf("%s/%s", fieldManager, subcontroller)
 	}
 
+	// Replace the whole object when its structure changed, so that none of the existing content
+	// is merged into it. It is applied after all the same, to keep the field ownership.
+	if existing != nil && shouldReplace(existing, obj) {
+		log.Printf("Object %s %s annotation changed, replacing it.", objDesc, names.ReplaceOnChangeAnnotation)
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return ApplyFailed, "", fmt.Errorf("could not convert %s for replacing: %w", objDesc, err)
+		}
+		replacement := &unstructured.Unstructured{Object: content}
+		replacement.SetResourceVersion(existing.GetResourceVersion())
+		_, err = clusterClient.Dynamic().Resource(rm.Resource).Namespace(namespace).Update(ctx, replacement,
+			metav1.UpdateOptions{FieldManager: fieldManager})
+		if err != nil {
+			return ApplyFailed, "", fmt.Errorf("failed to replace %s: %w", objDesc, err)
+		}
+	}
+
 	// Use server-side apply to merge the desired object with the object on disk
 	patchOptions := metav1.PatchOptions{
 		// It is considered best-practice for controllers to force
@@ -123,11 +278,11 @@ func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subco
 	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, obj)
 	if err != nil {
 		log.Printf("could not encode %s for apply", objDesc)
-		return fmt.Errorf("could not encode for patching: %w", err)
+		return ApplyFailed, "", fmt.Errorf("could not encode for patching: %w", err)
 	}
 	us, err := clusterClient.Dynamic().Resource(rm.Resource).Namespace(namespace).Patch(ctx, name, types.ApplyPatchType, data, patchOptions, subresources...)
 	if err != nil {
-		return fmt.Errorf("failed to apply / update %s: %w", objDesc, err)
+		return ApplyFailed, "", fmt.Errorf("failed to apply / update %s: %w", objDesc, err)
 	}
 
 	// consider removing in OCP 4.18 when we know field manager 'cluster-network-operator' no longer possibly
@@ -149,7 +304,26 @@ func ApplyObject(ctx context.Context, client cnoclient.Client, obj Object, subco
 		}
 	}
 	log.Printf("Apply / Create of %s was successful", objDesc)
-	return nil
+	switch {
+	case !retrieved:
+		return applyApplied, us.GetResourceVersion(), nil
+	case existing == nil:
+		return ApplyCreated, us.GetResourceVersion(), nil
+	case existing.GetResourceVersion() == us.GetResourceVersion():
+		return ApplyUnchanged, us.GetResourceVersion(), nil
+	default:
+		return ApplyUpdated, us.GetResourceVersion(), nil
+	}
+}
+
+// shouldReplace returns true if obj has the replace-on-change annotation and its value differs from
+// the existing object's
+func shouldReplace(existing *unstructured.Unstructured, obj Object) bool {
+	hash, ok := obj.GetAnnotations()[names.ReplaceOnChangeAnnotation]
+	if !ok {
+		return false
+	}
+	return existing.GetAnnotations()[names.ReplaceOnChangeAnnotation] != hash
 }
 
 func isDepFieldManagerCleanupNeeded(subcontroller string) bool {
@@ -0,0 +1,85 @@
+package apply
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestApplyResults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	applyErr := fmt.Errorf("failed to apply / update")
+	results := ApplyResults{
+		{GVK: deployment, Namespace: "openshift-multus", Name: "multus-admission-controller", Outcome: ApplyUpdated},
+		{GVK: configMap, Namespace: "openshift-multus", Name: "cni-copy-resources", Outcome: ApplyUnchanged},
+		{GVK: configMap, Namespace: "openshift-multus", Name: "multus-daemon-config", Outcome: ApplyUnchanged},
+		{GVK: configMap, Namespace: "openshift-multus", Name: "default-cni-sysctl-allowlist", Outcome: ApplySkipped},
+		{GVK: configMap, Namespace: "openshift-multus", Name: "broken", Outcome: ApplyFailed, Err: applyErr},
+		{GVK: deployment, Namespace: "openshift-multus", Name: "new", Outcome: ApplyCreated},
+	}
+
+	g.Expect(results.Summary()).To(Equal("1 created, 1 updated, 2 unchanged, 1 skipped, 1 error"))
+	failed := results.Failed()
+	g.Expect(failed).To(HaveLen(1))
+	g.Expect(failed[0].Err).To(MatchError(applyErr))
+	g.Expect(failed[0].String()).To(Equal("(/v1, Kind=ConfigMap) openshift-multus/broken: error"))
+
+	g.Expect(results[:3].Failed()).To(BeEmpty())
+	g.Expect(results[:3].Summary()).To(Equal("1 updated, 2 unchanged"))
+	g.Expect(ApplyResults{}.Summary()).To(Equal("no objects"))
+}
+
+func TestApplyTracker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tracker := &ApplyTracker{}
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	result := func(outcome ApplyOutcome, resourceVersion string) ApplyResult {
+		return ApplyResult{GVK: deployment, Namespace: "openshift-multus", Name: "multus-admission-controller",
+			Outcome: outcome, ResourceVersion: resourceVersion}
+	}
+	const key = "/apps/v1, Kind=Deployment/openshift-multus/multus-admission-controller"
+
+	// the first apply retrieves the object, so its outcome is already known
+	g.Expect(tracker.record(key, result(ApplyCreated, "1")).Outcome).To(Equal(ApplyCreated))
+	// later ones compare the resourceVersion with the previous apply
+	g.Expect(tracker.record(key, result(applyApplied, "1")).Outcome).To(Equal(ApplyUnchanged))
+	g.Expect(tracker.record(key, result(applyApplied, "2")).Outcome).To(Equal(ApplyUpdated))
+	// a failed apply doesn't forget the last applied resourceVersion
+	failed := tracker.record(key, ApplyResult{GVK: deployment, Outcome: ApplyFailed, Err: fmt.Errorf("failed to apply / update")})
+	g.Expect(failed.Outcome).To(Equal(ApplyFailed))
+	g.Expect(tracker.record(key, result(applyApplied, "2")).Outcome).To(Equal(ApplyUnchanged))
+}
+
+func TestShouldReplace(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	withHash := func(hash string, content string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("admissionregistration.k8s.io/v1")
+		obj.SetKind("ValidatingWebhookConfiguration")
+		obj.SetName("multus.openshift.io")
+		obj.SetLabels(map[string]string{"content": content})
+		if hash != "" {
+			obj.SetAnnotations(map[string]string{names.ReplaceOnChangeAnnotation: hash})
+		}
+		return obj
+	}
+
+	existing := withHash("rules-1", "a")
+	// an unrelated change is merged
+	g.Expect(shouldReplace(existing, withHash("rules-1", "b"))).To(BeFalse())
+	// a structural change replaces the object
+	g.Expect(shouldReplace(existing, withHash("rules-2", "a"))).To(BeTrue())
+	// as does setting the hash on an object that didn't have it
+	g.Expect(shouldReplace(withHash("", "a"), withHash("rules-1", "a"))).To(BeTrue())
+	// objects without the annotation are always merged
+	g.Expect(shouldReplace(existing, withHash("", "b"))).To(BeFalse())
+}
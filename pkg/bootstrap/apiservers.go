@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ValidateAPIServers checks that the Infra.APIServers of bootstrapResult have all of requiredKeys,
+// each with a host and a port. The errors of all the keys are aggregated.
+func ValidateAPIServers(bootstrapResult *BootstrapResult, requiredKeys []string) error {
+	if bootstrapResult == nil {
+		return fmt.Errorf("bootstrap result is missing")
+	}
+	var errs []error
+	for _, key := range requiredKeys {
+		apiServer, ok := bootstrapResult.Infra.APIServers[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("bootstrap result is missing Infra.APIServers[%s]", key))
+			continue
+		}
+		if apiServer.Host == "" {
+			errs = append(errs, fmt.Errorf("bootstrap result Infra.APIServers[%s] has an empty host", key))
+		}
+		if apiServer.Port == "" {
+			errs = append(errs, fmt.Errorf("bootstrap result Infra.APIServers[%s] has an empty port", key))
+		}
+	}
+	return k8serrors.NewAggregate(errs)
+}
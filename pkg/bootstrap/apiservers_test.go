@@ -0,0 +1,42 @@
+package bootstrap
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// TestValidateAPIServers tests checking the required Infra.APIServers entries
+func TestValidateAPIServers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := &BootstrapResult{
+		Infra: InfraStatus{
+			APIServers: map[string]APIServer{
+				APIServerDefault:      {Host: "api.example.com", Port: "6443"},
+				APIServerDefaultLocal: {Host: "api-int.example.com", Port: "6443"},
+			},
+		},
+	}
+	g.Expect(ValidateAPIServers(bootstrapResult, nil)).To(Succeed())
+	g.Expect(ValidateAPIServers(bootstrapResult, []string{APIServerDefault, APIServerDefaultLocal})).To(Succeed())
+
+	err := ValidateAPIServers(bootstrapResult, []string{APIServerDefault, "hosted"})
+	g.Expect(err).To(MatchError("bootstrap result is missing Infra.APIServers[hosted]"))
+
+	bootstrapResult.Infra.APIServers[APIServerDefault] = APIServer{}
+	bootstrapResult.Infra.APIServers[APIServerDefaultLocal] = APIServer{Host: "api-int.example.com"}
+	err = ValidateAPIServers(bootstrapResult, []string{APIServerDefault, APIServerDefaultLocal, "hosted"})
+	g.Expect(err).To(HaveOccurred())
+	for _, want := range []string{
+		"Infra.APIServers[default] has an empty host",
+		"Infra.APIServers[default] has an empty port",
+		"Infra.APIServers[default-local] has an empty port",
+		"missing Infra.APIServers[hosted]",
+	} {
+		g.Expect(err.Error()).To(ContainSubstring(want))
+	}
+	g.Expect(err.Error()).NotTo(ContainSubstring("Infra.APIServers[default-local] has an empty host"))
+
+	g.Expect(ValidateAPIServers(nil, []string{APIServerDefault})).To(MatchError("bootstrap result is missing"))
+}
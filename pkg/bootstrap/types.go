@@ -1,8 +1,14 @@
 package bootstrap
 
 import (
+	"time"
+
 	configv1 "github.com/openshift/api/config/v1"
 	hyperv1 "github.com/openshift/hypershift/api/v1beta1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type OVNHyperShiftBootstrapResult struct {
@@ -63,9 +69,344 @@ type OVNBootstrapResult struct {
 	FlowsConfig           *FlowsConfig
 }
 
+// MultusAdmissionControllerHyperShiftBootstrapResult is the HyperShift configuration
+// the multus admission controller is rendered with
+type MultusAdmissionControllerHyperShiftBootstrapResult struct {
+	Enabled      bool
+	Namespace    string
+	RunAsUser    string
+	ReleaseImage string
+}
+
+// MultusAdmissionControllerObjectAnnotations are annotations added to the rendered multus
+// admission controller objects of a kind, and of a name if set
+type MultusAdmissionControllerObjectAnnotations struct {
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name,omitempty"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// MultusAdmissionControllerBootstrapResult contains the overrides read from the
+// openshift-network-operator/multus-admission-controller-config ConfigMap. Zero values
+// mean the render defaults are used.
+type MultusAdmissionControllerBootstrapResult struct {
+	HyperShiftConfig *MultusAdmissionControllerHyperShiftBootstrapResult
+
+	// TokenMountPath is the directory where the hosted cluster token and kubeconfig
+	// are shared between containers, in HyperShift
+	TokenMountPath string
+
+	// AuditLogDir is the directory the admission controller writes its audit log of admission
+	// decisions to. The audit log, and its volume, are only rendered if it is set
+	AuditLogDir string
+
+	// AuditLogHostPath is the node directory backing AuditLogDir, for collecting the audit log
+	// from the nodes. The audit log is on an emptyDir by default
+	AuditLogHostPath string
+
+	// IPAMHints passes the cluster IPAM context (service and cluster networks, whereabouts)
+	// to the admission controller, so it can warn about NADs conflicting with reserved ranges
+	IPAMHints bool
+
+	// LogLevel is the admission controller log verbosity, if set
+	LogLevel *int
+
+	// WebhookWorkerCount is the number of admission controller webhook workers, if set
+	WebhookWorkerCount *int
+
+	// MetricsAuth selects how the admission controller metrics are protected:
+	// "kube-rbac-proxy" (the default) or "none"
+	MetricsAuth string
+
+	// NamespacePolicy selects whether the HyperShift admission controller namespace must
+	// already exist ("require-exists", the default) or is rendered ("render")
+	NamespacePolicy string
+
+	// TopologyMode is the webhook Service topology-mode annotation, "Auto" for topology aware
+	// routing. It is only rendered if the replicas can span more than one zone
+	TopologyMode string
+
+	// SessionAffinity is the webhook Service session affinity, None by default
+	SessionAffinity corev1.ServiceAffinity
+
+	// SessionAffinityTimeout is the ClientIP session affinity timeout in seconds, if set
+	SessionAffinityTimeout *int
+
+	// InternalTrafficPolicy is the webhook Service internal traffic policy, Cluster by default
+	InternalTrafficPolicy corev1.ServiceInternalTrafficPolicy
+
+	// DNSPolicy is the admission controller pod DNS policy, ClusterFirst by default
+	DNSPolicy corev1.DNSPolicy
+
+	// DNSNameservers are the admission controller pod dnsConfig nameservers
+	DNSNameservers []string
+
+	// DNSSearches are the admission controller pod dnsConfig search domains
+	DNSSearches []string
+
+	// AdditionalCNIPlugins are CNI plugins installed on the nodes other than by the operator, for
+	// NADs of third party plugin types
+	AdditionalCNIPlugins []string
+
+	// AdmissionReviewVersions override the AdmissionReview versions the webhook advertises, which
+	// are otherwise derived from the API server version
+	AdmissionReviewVersions []string
+
+	// Revision suffixes the admission controller Deployment name, if set, so that
+	// two revisions can run side by side during an upgrade
+	Revision string
+
+	// PreviousRevision is the revision retained alongside Revision, if set
+	PreviousRevision string
+
+	// PrunePreviousRevision removes PreviousRevision once Revision is available
+	PrunePreviousRevision bool
+
+	// MeshInjection allows service mesh sidecar injection into the admission controller pod,
+	// which is disabled by default
+	MeshInjection *bool
+
+	// AutomountServiceAccountToken overrides the admission controller pod automountServiceAccountToken.
+	// When disabled outside of HyperShift the token is projected explicitly
+	AutomountServiceAccountToken *bool
+
+	// ImageDigests maps image references to their sha256 digest, to render the admission
+	// controller image pinned by digest
+	ImageDigests map[string]string
+
+	// KubeRBACProxyFallbackImage is the kube-rbac-proxy image used when KUBE_RBAC_PROXY_IMAGE
+	// isn't set, as in development builds. The environment variable takes precedence
+	KubeRBACProxyFallbackImage string
+
+	// IgnoredNamespacesRefreshInterval is how often the ignored namespaces are rediscovered.
+	// Unset, they are only discovered until a discovery succeeds.
+	IgnoredNamespacesRefreshInterval *time.Duration
+
+	// IgnoredNamespacesGracePeriod is how long the last discovered ignored namespaces are kept
+	// while their rediscovery fails, 30m by default
+	IgnoredNamespacesGracePeriod *time.Duration
+
+	// ReconcileInterval is a global resync hint: it backs off the periodic resync of the whole
+	// operator configuration, not only of the admission controller objects, to that interval.
+	// The operator resync period by default.
+	ReconcileInterval *time.Duration
+
+	// TokenMinterAPIServer is the Infra.APIServers key the HyperShift token minter reaches the
+	// hosted cluster API with, APIServerDefaultLocal by default
+	TokenMinterAPIServer string
+
+	// TokenMinterRequestTimeout bounds the HyperShift token minter API requests, 30s by default
+	TokenMinterRequestTimeout *time.Duration
+
+	// FSGroup is the admission controller pod fsGroup, assigned by the SCC if unset
+	FSGroup *int
+
+	// SupplementalGroups are the admission controller pod supplementalGroups, assigned by the
+	// SCC if unset
+	SupplementalGroups []int
+
+	// RevisionHistoryLimit is the admission controller Deployment revisionHistoryLimit, 2 by default
+	RevisionHistoryLimit *int
+
+	// ProgressDeadlineSeconds is the admission controller Deployment progressDeadlineSeconds, 600
+	// by default; it must exceed the startup probe budget
+	ProgressDeadlineSeconds *int
+
+	// MetricsPort is the port metrics are scraped from, 8443 by default
+	MetricsPort *int
+
+	// MetricsPath is the path metrics are scraped from, /metrics by default
+	MetricsPath string
+
+	// ProbeInitialDelaySeconds, ProbePeriodSeconds, ProbeTimeoutSeconds and ProbeFailureThreshold
+	// override the readiness and liveness probe timings, if set and HealthProbes is on
+	ProbeInitialDelaySeconds *int
+	ProbePeriodSeconds       *int
+	ProbeTimeoutSeconds      *int
+	ProbeFailureThreshold    *int
+
+	// StartupProbePeriodSeconds and StartupProbeFailureThreshold override the startup probe
+	// timings, if set; the liveness probe only starts once the startup probe succeeded
+	StartupProbePeriodSeconds    *int
+	StartupProbeFailureThreshold *int
+
+	// PreStopSleepSeconds is how long the admission controller keeps serving once terminating,
+	// 5 seconds by default. 0 disables the preStop hook
+	PreStopSleepSeconds *int
+
+	// ShutdownTimeout is how long the admission controller waits for the reviews in flight once
+	// terminating, after the preStop sleep. Unset leaves the admission controller default
+	ShutdownTimeout *time.Duration
+
+	// EphemeralStorageRequest and EphemeralStorageLimit override the admission controller
+	// container ephemeral storage request and limit, 50Mi and 1Gi by default
+	EphemeralStorageRequest *resource.Quantity
+	EphemeralStorageLimit   *resource.Quantity
+
+	// ScratchVolumeSizeLimit is the sizeLimit of the emptyDir scratch volumes, 100Mi by default
+	ScratchVolumeSizeLimit *resource.Quantity
+
+	// Autoscaling renders a HorizontalPodAutoscaler for the admission controller Deployment, if the
+	// cluster serves autoscaling/v2, in place of its static replica count
+	Autoscaling bool
+
+	// AutoscalingMinReplicas and AutoscalingMaxReplicas bound the autoscaled replicas, from the
+	// topology replica count to 6 by default
+	AutoscalingMinReplicas *int
+	AutoscalingMaxReplicas *int
+
+	// AutoscalingCPUUtilization and AutoscalingMemoryUtilization are the average utilization
+	// targets, in percent of the requests. The CPU target is 80 unless only memory is set
+	AutoscalingCPUUtilization    *int
+	AutoscalingMemoryUtilization *int
+
+	// SeccompProfileType is the admission controller containers seccomp profile type,
+	// RuntimeDefault by default
+	SeccompProfileType corev1.SeccompProfileType
+
+	// SeccompLocalhostProfile is the Localhost seccomp profile, relative to the kubelet
+	// seccomp profile root
+	SeccompLocalhostProfile string
+
+	// RuntimeClassName is the RuntimeClass of the admission controller pods, such as a gVisor or
+	// Kata one, if set. The RuntimeClass must exist
+	RuntimeClassName string
+
+	// MultusAffinity prefers scheduling the admission controller on nodes running multus
+	MultusAffinity bool
+
+	// DisableServiceMonitor suppresses the admission controller ServiceMonitor, for clusters
+	// that scrape its metrics some other way
+	DisableServiceMonitor bool
+
+	// MetricsService serves the admission controller metrics through a dedicated Service,
+	// selected by the ServiceMonitor, instead of a port of the webhook Service
+	MetricsService bool
+
+	// RoleAggregation renders an aggregated ClusterRole bound to the admission controller, so
+	// that admins can grant it additional permissions with labelled ClusterRoles
+	RoleAggregation bool
+
+	// SplitRBAC renders the read and the write permissions of the admission controller in
+	// separate ClusterRoles and bindings, instead of a single combined ClusterRole
+	SplitRBAC bool
+
+	// IgnoredNamespacesHash annotates the webhook configuration with a hash of the ignored
+	// namespaces, so that changes to the ignore list can be detected
+	IgnoredNamespacesHash bool
+
+	// RejectionEvents has the admission controller emit an Event on each rejected
+	// NetworkAttachmentDefinition, and grants it permission to create them in all namespaces
+	RejectionEvents bool
+
+	// RequireClusterID fails the HyperShift render while the HostedControlPlane has no cluster
+	// ID, instead of rendering the metrics without the cluster ID label
+	RequireClusterID bool
+
+	// HealthProbes renders the readiness, startup and liveness probes against the webhook
+	// /readyz and /livez endpoints. Off by default, since not every admission controller
+	// image serves them, and a probe on a missing path would keep the pods unready
+	HealthProbes bool
+
+	// SchemaValidation validates the rendered objects against the cluster OpenAPI schema.
+	// Off by default, since fetching the schema is expensive
+	SchemaValidation bool
+
+	// DevelopmentMode allows the overrides meant for debugging only
+	DevelopmentMode bool
+
+	// CommandOverride replaces the admission controller container command, if set.
+	// It requires DevelopmentMode
+	CommandOverride []string
+
+	// OutputFormat is "Raw" (the default), to render the objects themselves, or "Template", to
+	// wrap them in an OpenShift Template parameterized by namespace, image and replicas
+	OutputFormat string
+
+	// CertReloadStrategy is "PodRestart" (the default), to roll out the pods when the serving
+	// certificate changes, or "FileWatch", to have the webhook reload it in place
+	CertReloadStrategy string
+
+	// ImagePullPolicy is the pull policy of the admission controller containers, IfNotPresent
+	// by default
+	ImagePullPolicy corev1.PullPolicy
+
+	// WebhookRegistration is "immediate" (the default) or "after-ready", to hold back the
+	// creation of the webhook configuration until the controller endpoints are ready
+	WebhookRegistration string
+
+	// WebhookMatchPolicy is the webhook matchPolicy, Equivalent by default, to match the writes of
+	// all the served NetworkAttachmentDefinition versions to the newest, or Exact, to list them all
+	WebhookMatchPolicy admissionregistrationv1.MatchPolicyType
+
+	// WebhookUpdateStrategy is "Apply" (the default), to apply the webhook configuration like
+	// any other object, or "ReplaceOnRulesChange", to replace it when its rules change
+	WebhookUpdateStrategy string
+
+	// RunAsUserSource is "Static" (the default), to run the pod as the render's user, or
+	// "NamespaceRange", to run it as the first UID of the namespace's SCC UID range
+	RunAsUserSource string
+
+	// HostAliases are added to the HyperShift admission controller pod, for management
+	// cluster hostnames the cluster DNS doesn't resolve
+	HostAliases []corev1.HostAlias
+
+	// ExtraWebhookRules are validated by the admission controller in addition to the
+	// NetworkAttachmentDefinitions
+	ExtraWebhookRules []admissionregistrationv1.RuleWithOperations
+
+	// WebhookObjectSelector is the label selector of the objects the webhook validates, in
+	// the kubectl selector syntax. All the objects are validated by default
+	WebhookObjectSelector string
+
+	// NamespaceSelectorStrategy also excludes the ignored namespaces from the webhook through its
+	// namespaceSelector: "Names" lists them, "Labels" selects on a label the operator sets on
+	// them. Unset, they are only ignored by the admission controller
+	NamespaceSelectorStrategy string
+
+	// SideEffects is the validating webhook sideEffects class, NoneOnDryRun by default
+	SideEffects admissionregistrationv1.SideEffectClass
+
+	// Paused scales the admission controller Deployment to zero replicas, keeping all its
+	// objects, to temporarily stop enforcement
+	Paused bool
+
+	// FailurePolicy is the webhook failurePolicy, Fail by default
+	FailurePolicy admissionregistrationv1.FailurePolicyType
+
+	// PausedFailurePolicy is the webhook failurePolicy while paused, Ignore by default
+	PausedFailurePolicy admissionregistrationv1.FailurePolicyType
+
+	// ServiceCAKey is the key of the service CA bundle in the openshift-service-ca.crt ConfigMap
+	// of the admission controller namespace, service-ca.crt by default
+	ServiceCAKey string
+
+	// ServingCertSecret is an externally provisioned webhook serving certificate Secret, used
+	// instead of the one generated by the service CA operator
+	ServingCertSecret string
+
+	// ServiceAccountName is the admission controller ServiceAccount, multus-ac by default
+	ServiceAccountName string
+
+	// MaxUnavailable is the admission controller Deployment rolling update maxUnavailable, if set
+	MaxUnavailable *intstr.IntOrString
+
+	// MaxSurge is the admission controller Deployment rolling update maxSurge, if set
+	MaxSurge *intstr.IntOrString
+
+	// ExtraEnv are environment variables added to the webhook container, for feature flags and
+	// debug toggles. They can't override the variables the render sets.
+	ExtraEnv []corev1.EnvVar
+
+	// BackupAnnotations are added to the selected objects for backup and restore tooling, such
+	// as Velero. They never replace an annotation the render sets.
+	BackupAnnotations []MultusAdmissionControllerObjectAnnotations
+}
+
 type BootstrapResult struct {
-	OVN   OVNBootstrapResult
-	Infra InfraStatus
+	OVN                       OVNBootstrapResult
+	Infra                     InfraStatus
+	MultusAdmissionController MultusAdmissionControllerBootstrapResult
 }
 
 type InfraStatus struct {
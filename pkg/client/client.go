@@ -133,7 +133,11 @@ func (c *OperatorClient) ClientFor(name string) ClusterClient {
 	if len(name) == 0 {
 		return c.Default()
 	}
-	return c.clusterClients[name]
+	// return an untyped nil for unknown clusters, so that callers can check for nil
+	if cc, ok := c.clusterClients[name]; ok {
+		return cc
+	}
+	return nil
 }
 
 func (c *OperatorClient) Default() ClusterClient {
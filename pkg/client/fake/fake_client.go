@@ -48,7 +48,11 @@ func (fc *FakeClient) ClientFor(name string) cnoclient.ClusterClient {
 	if len(name) == 0 {
 		return fc.Default()
 	}
-	return fc.clusterClients[name]
+	// return an untyped nil for unknown clusters, so that callers can check for nil
+	if cc, ok := fc.clusterClients[name]; ok {
+		return cc
+	}
+	return nil
 }
 
 func (fc *FakeClient) Default() cnoclient.ClusterClient {
@@ -87,6 +91,29 @@ func isOpenShiftObject(obj crclient.Object) bool {
 //
 //	(this is't that big a deal since we don't actually use the typed client that much).
 func NewFakeClient(objs ...crclient.Object) cnoclient.Client {
+	return &FakeClient{
+		clusterClients: map[string]*FakeClusterClient{
+			names.DefaultClusterName: newFakeClusterClient(objs...),
+		},
+	}
+}
+
+// NewFakeClientWithClusters creates a fake client like NewFakeClient, with additional
+// named cluster clients (e.g. the HyperShift management cluster), each backed by
+// its own store containing the given objects.
+func NewFakeClientWithClusters(clusterObjs map[string][]crclient.Object, objs ...crclient.Object) cnoclient.Client {
+	fc := &FakeClient{
+		clusterClients: map[string]*FakeClusterClient{
+			names.DefaultClusterName: newFakeClusterClient(objs...),
+		},
+	}
+	for name, cobjs := range clusterObjs {
+		fc.clusterClients[name] = newFakeClusterClient(cobjs...)
+	}
+	return fc
+}
+
+func newFakeClusterClient(objs ...crclient.Object) *FakeClusterClient {
 	// silly go type conversion
 	oo := make([]runtime.Object, 0, len(objs))
 	ooTyped := make([]runtime.Object, 0, len(objs))
@@ -97,17 +124,11 @@ func NewFakeClient(objs ...crclient.Object) cnoclient.Client {
 		}
 	}
 	co := &configv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: ""}}
-	fc := FakeClusterClient{
+	return &FakeClusterClient{
 		kClient:   faketyped.NewSimpleClientset(ooTyped...),
 		dynclient: fakedynamic.NewSimpleDynamicClient(scheme.Scheme, oo...),
 		crclient:  crfake.NewClientBuilder().WithStatusSubresource(co).WithObjects(objs...).Build(),
 	}
-
-	return &FakeClient{
-		clusterClients: map[string]*FakeClusterClient{
-			names.DefaultClusterName: &fc,
-		},
-	}
 }
 
 type fakeRESTMapper struct {
@@ -0,0 +1,54 @@
+package operconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// multusAdmissionControllerCAVerifyTimeout bounds the TLS handshake with the multus admission
+// controller webhook
+const multusAdmissionControllerCAVerifyTimeout = 5 * time.Second
+
+// verifyMultusAdmissionControllerCA checks in the background that the multus admission controller
+// serves a certificate trusted by the caBundle of the rendered webhook configuration, unless a
+// check is still in progress. Only a certificate verification failure degrades the operator:
+// any other error, like the controller not serving yet, says nothing about the CA and clears the
+// condition.
+func (r *ReconcileOperConfig) verifyMultusAdmissionControllerCA(objs []*uns.Unstructured) {
+	if !r.multusCAVerifying.CompareAndSwap(false, true) {
+		return
+	}
+	webhooks := []*uns.Unstructured{}
+	for _, obj := range objs {
+		if obj.GetKind() == "ValidatingWebhookConfiguration" {
+			webhooks = append(webhooks, obj.DeepCopy())
+		}
+	}
+	go func() {
+		defer r.multusCAVerifying.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), multusAdmissionControllerCAVerifyTimeout)
+		defer cancel()
+
+		err := network.VerifyMultusAdmissionControllerCA(ctx, webhooks)
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			log.Printf("Multus admission controller CA is not trusted: %v", err)
+			r.status.SetDegraded(statusmanager.MultusAdmissionControllerCA, "MultusAdmissionControllerCAUntrusted",
+				fmt.Sprintf("Multus admission controller serving certificate is not trusted by the webhook caBundle: %v", err))
+			return
+		}
+		if err != nil {
+			log.Printf("Could not verify multus admission controller CA: %v", err)
+		}
+		r.status.SetNotDegraded(statusmanager.MultusAdmissionControllerCA)
+	}()
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -212,6 +213,12 @@ type ReconcileOperConfig struct {
 	mtuProberCleanedUp bool
 	// maintain the copy of feature gates in the cluster
 	featureGates featuregates.FeatureGate
+
+	// If a multus admission controller CA check is in progress.
+	multusCAVerifying atomic.Bool
+
+	// Tracks the applied objects, to report whether they changed.
+	applyTracker apply.ApplyTracker
 }
 
 // Reconcile updates the state of the cluster to match that which is desired
@@ -352,8 +359,14 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	objs, progressing, err := network.Render(&operConfig.Spec, bootstrapResult, ManifestPath, r.client, r.featureGates)
 	if err != nil {
 		log.Printf("Failed to render: %v", err)
-		r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
-			fmt.Sprintf("Internal error while rendering operator configuration: %v", err))
+		var mgmtErr *network.ManagementClusterUnavailableError
+		if errors.As(err, &mgmtErr) {
+			r.status.SetDegraded(statusmanager.OperatorConfig, "ManagementClusterUnavailable",
+				fmt.Sprintf("Could not reach the management cluster while rendering operator configuration: %v", err))
+		} else {
+			r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
+				fmt.Sprintf("Internal error while rendering operator configuration: %v", err))
+		}
 		return reconcile.Result{}, err
 	}
 
@@ -444,6 +457,8 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	// Apply the objects to the cluster
 	setDegraded := false
 	var degradedErr error
+	var applyResults apply.ApplyResults
+	var degradedObjects []string
 	for _, obj := range objs {
 		// TODO: OwnerRef for non default clusters. For HyperShift this should probably be HostedControlPlane CR
 		if apply.GetClusterName(obj) == "" {
@@ -458,7 +473,9 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 		}
 
 		// Open question: should an error here indicate we will never retry?
-		if err := apply.ApplyObject(ctx, r.client, obj, ControllerName); err != nil {
+		result := r.applyTracker.ApplyObject(ctx, r.client, obj, ControllerName)
+		applyResults = append(applyResults, result)
+		if err := result.Err; err != nil {
 			err = errors.Wrapf(err, "could not apply (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 
 			// If error comes from nonexistent namespace print out a help message.
@@ -478,15 +495,52 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 			}
 			setDegraded = true
 			degradedErr = err
+			degradedObjects = append(degradedObjects, fmt.Sprintf("(%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()))
 		}
 	}
+	if failed := applyResults.Failed(); len(failed) > 0 {
+		log.Printf("Applied operator configuration: %s; failed: %v", applyResults.Summary(), failed)
+	} else {
+		log.Printf("Applied operator configuration: %s", applyResults.Summary())
+	}
 
 	if setDegraded {
-		r.status.SetDegraded(statusmanager.OperatorConfig, "ApplyOperatorConfig",
-			fmt.Sprintf("Error while updating operator configuration: %v", degradedErr))
+		message := fmt.Sprintf("Error while updating operator configuration: %v", degradedErr)
+		if len(degradedObjects) > 1 {
+			message = fmt.Sprintf("Error while updating operator configuration, %d objects failed to apply (%s): %v",
+				len(degradedObjects), strings.Join(degradedObjects, ", "), degradedErr)
+		}
+		r.status.SetDegraded(statusmanager.OperatorConfig, "ApplyOperatorConfig", message)
 		return reconcile.Result{}, degradedErr
 	}
 
+	// Check that the multus admission controller serves a certificate trusted by the rendered
+	// caBundle. The check dials the webhook, so it runs in the background for an
+	// unreachable webhook not to hold up the reconcile.
+	r.verifyMultusAdmissionControllerCA(objs)
+
+	// A deleted serving Secret leaves the multus admission controller failing TLS until the
+	// service CA operator generates it again, which the check nudges it to do.
+	if err := network.RecoverMultusAdmissionControllerServingSecret(ctx, r.client, objs, time.Now()); err != nil {
+		var missingErr *network.MultusAdmissionControllerServingSecretMissingError
+		if errors.As(err, &missingErr) {
+			log.Printf("Multus admission controller serving secret is missing: %v", err)
+			r.status.SetDegraded(statusmanager.MultusAdmissionControllerServingSecret, "MultusAdmissionControllerServingSecretMissing",
+				fmt.Sprintf("Multus admission controller serving secret %s/%s is missing, waiting for the service CA operator to generate it",
+					missingErr.Namespace, missingErr.Name))
+		} else {
+			log.Printf("Could not check the multus admission controller serving secret: %v", err)
+		}
+	} else {
+		r.status.SetNotDegraded(statusmanager.MultusAdmissionControllerServingSecret)
+	}
+
+	// The Labels namespace selector strategy of the multus admission controller webhook relies on
+	// the ignored namespaces being labeled, and no other strategy leaves the label behind.
+	if err := network.LabelMultusAdmissionControllerIgnoredNamespaces(ctx, r.client, &bootstrapResult.MultusAdmissionController); err != nil {
+		log.Printf("Could not label the namespaces ignored by the multus admission controller: %v", err)
+	}
+
 	if operConfig.Spec.Migration != nil && operConfig.Spec.Migration.NetworkType != "" {
 		if !(operConfig.Spec.Migration.NetworkType == string(operv1.NetworkTypeOpenShiftSDN) || operConfig.Spec.Migration.NetworkType == string(operv1.NetworkTypeOVNKubernetes)) {
 			err = fmt.Errorf("Error: operConfig.Spec.Migration.NetworkType: %s is not equal to either \"OpenshiftSDN\" or \"OVNKubernetes\"", operConfig.Spec.Migration.NetworkType)
@@ -539,10 +593,10 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 
 	r.status.SetNotDegraded(statusmanager.OperatorConfig)
 
-	// All was successful. Request that this be re-triggered after ResyncPeriod,
-	// so we can reconcile state again.
+	// All was successful. Request that this be re-triggered after ResyncPeriod, or the
+	// global resync hint of the rendered objects, so we can reconcile state again.
 	log.Printf("Operconfig Controller complete")
-	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
+	return reconcile.Result{RequeueAfter: globalResyncPeriod(objs, ResyncPeriod)}, nil
 }
 
 func reconcileOperConfig(ctx context.Context, obj crclient.Object) []reconcile.Request {
@@ -0,0 +1,44 @@
+package operconfig
+
+import (
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// reconcileInterval returns the reconcile interval annotation of obj, or 0 if it has none or
+// it doesn't parse.
+func reconcileInterval(obj *uns.Unstructured) time.Duration {
+	value, ok := obj.GetAnnotations()[names.ReconcileIntervalAnnotation]
+	if !ok {
+		return 0
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		klog.Warningf("Ignoring invalid %s annotation %q of (%s) %s/%s: %v",
+			names.ReconcileIntervalAnnotation, value, obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+		return 0
+	}
+	return interval
+}
+
+// globalResyncPeriod returns how long to wait before the next periodic resync: the shortest
+// reconcile interval of objs, or resync if none of them has one longer than that. The hint is
+// global, an interval on any object backs off the resync of every network component, not only of
+// the annotated objects. Events of the watched objects still trigger a reconcile right away, but a
+// drift of the objects the controller doesn't watch is only repaired at the next resync.
+func globalResyncPeriod(objs []*uns.Unstructured, resync time.Duration) time.Duration {
+	var shortest time.Duration
+	for _, obj := range objs {
+		if interval := reconcileInterval(obj); interval > 0 && (shortest == 0 || interval < shortest) {
+			shortest = interval
+		}
+	}
+	if shortest <= resync {
+		return resync
+	}
+	return shortest
+}
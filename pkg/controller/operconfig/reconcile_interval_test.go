@@ -0,0 +1,41 @@
+package operconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	. "github.com/onsi/gomega"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGlobalResyncPeriod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newObj := func(interval string) *uns.Unstructured {
+		obj := &uns.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("openshift-multus")
+		obj.SetName("test")
+		if interval != "" {
+			obj.SetAnnotations(map[string]string{names.ReconcileIntervalAnnotation: interval})
+		}
+		return obj
+	}
+	resync := 3 * time.Minute
+
+	// objects without the annotation keep the resync period
+	g.Expect(globalResyncPeriod(nil, resync)).To(Equal(resync))
+	g.Expect(globalResyncPeriod([]*uns.Unstructured{newObj("")}, resync)).To(Equal(resync))
+
+	// the shortest interval wins
+	g.Expect(globalResyncPeriod([]*uns.Unstructured{newObj(""), newObj("30m"), newObj("10m")}, resync)).To(Equal(10 * time.Minute))
+
+	// intervals never shorten the resync period
+	g.Expect(globalResyncPeriod([]*uns.Unstructured{newObj("1m"), newObj("10m")}, resync)).To(Equal(resync))
+
+	// invalid intervals are ignored
+	g.Expect(globalResyncPeriod([]*uns.Unstructured{newObj("often"), newObj("10m")}, resync)).To(Equal(10 * time.Minute))
+}
@@ -52,6 +52,8 @@ const (
 	RolloutHung
 	CertificateSigner
 	InfrastructureConfig
+	MultusAdmissionControllerCA
+	MultusAdmissionControllerServingSecret
 	maxStatusLevel
 )
 
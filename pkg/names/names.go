@@ -94,6 +94,19 @@ const CopyFromAnnotation = "network.operator.openshift.io/copy-from"
 // ClusterNameAnnotation is an annotation that specifies the cluster an object belongs to
 const ClusterNameAnnotation = "network.operator.openshift.io/cluster-name"
 
+// ContentGenerationAnnotation is an annotation with the hash of a rendered object's content,
+// used to skip objects that haven't changed since they were last applied
+const ContentGenerationAnnotation = "network.operator.openshift.io/content-generation"
+
+// ReconcileIntervalAnnotation is an annotation with a hint, as a duration, of how long the
+// operator waits between its periodic resyncs. It is a global hint: the operator resyncs all of
+// its objects, not only the annotated ones, at the shortest interval of the rendered objects.
+const ReconcileIntervalAnnotation = "network.operator.openshift.io/reconcile-interval"
+
+// ReplaceOnChangeAnnotation is an annotation with a hash of the structural content of an object,
+// which is replaced, rather than applied, when the hash differs from the existing object's
+const ReplaceOnChangeAnnotation = "network.operator.openshift.io/replace-on-change"
+
 // RelatedClusterObjectsAnnotation is an annotation that allows deleting resources for specified clusters
 // value format: cluster/group/resource/namespace/name
 const RelatedClusterObjectsAnnotation = "network.operator.openshift.io/relatedClusterObjects"
@@ -102,6 +115,10 @@ const RelatedClusterObjectsAnnotation = "network.operator.openshift.io/relatedCl
 // that is used in multus admission controller deployment
 const MULTUS_VALIDATING_WEBHOOK = "multus.openshift.io"
 
+// MULTUS_ADMISSION_CONTROLLER_CONFIG is the name of the ConfigMap in APPLIED_NAMESPACE
+// holding optional overrides for the multus admission controller render
+const MULTUS_ADMISSION_CONTROLLER_CONFIG = "multus-admission-controller-config"
+
 // ADDL_TRUST_BUNDLE_CONFIGMAP_NS is the namespace for one or more
 // ConfigMaps that contain user provided trusted CA bundles.
 const ADDL_TRUST_BUNDLE_CONFIGMAP_NS = "openshift-config"
@@ -18,6 +18,12 @@ func Bootstrap(conf *operv1.Network, client cnoclient.Client) (*bootstrap.Bootst
 	}
 	out.Infra = *infraStatus
 
+	mac, err := bootstrapMultusAdmissionController(client)
+	if err != nil {
+		return nil, err
+	}
+	out.MultusAdmissionController = *mac
+
 	switch conf.Spec.DefaultNetwork.Type {
 	case operv1.NetworkTypeOVNKubernetes:
 		o, err := bootstrapOVN(conf, client, infraStatus)
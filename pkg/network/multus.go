@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/cluster-network-operator/pkg/render"
 	"github.com/pkg/errors"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -19,6 +20,36 @@ const (
 	MultusSocketParentDir = "/run/multus"
 )
 
+// multusCNIPlugins are the CNI plugins the multus DaemonSet copies into CNIBinDir. Keep in sync with
+// the binary copy init containers of multus-additional-cni-plugins.
+var multusCNIPlugins = []string{
+	// cni-plugins
+	"bandwidth", "bridge", "dhcp", "dummy", "firewall", "host-device", "host-local", "ipvlan", "loopback",
+	"macvlan", "portmap", "ptp", "sbr", "static", "tap", "tuning", "vlan", "vrf",
+	// bond-cni-plugin
+	"bond",
+	// egress-router-binary-copy
+	"egress-router",
+	// routeoverride-cni
+	"route-override",
+	// whereabouts-cni-bincopy
+	"whereabouts",
+}
+
+// getInstalledCNIPlugins returns the sorted names of the CNI plugins installed on the cluster nodes:
+// the ones installed by multus, the default network plugin and the given additional plugins
+func getInstalledCNIPlugins(conf *operv1.NetworkSpec, additional []string) []string {
+	plugins := sets.New[string](multusCNIPlugins...)
+	switch conf.DefaultNetwork.Type {
+	case operv1.NetworkTypeOVNKubernetes:
+		plugins.Insert("ovn-k8s-cni-overlay")
+	case operv1.NetworkTypeOpenShiftSDN:
+		plugins.Insert("openshift-sdn")
+	}
+	plugins.Insert(additional...)
+	return sets.List(plugins)
+}
+
 // renderMultus generates the manifests of Multus
 func renderMultus(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, manifestDir string) ([]*uns.Unstructured, error) {
 	if *conf.DisableMultiNetwork {
@@ -2,32 +2,522 @@ package network
 
 import (
 	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	templatev1 "github.com/openshift/api/template/v1"
 	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
 	cnoclient "github.com/openshift/cluster-network-operator/pkg/client"
 	"github.com/openshift/cluster-network-operator/pkg/platform"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/version"
 
 	"github.com/openshift/cluster-network-operator/pkg/names"
 	"github.com/openshift/cluster-network-operator/pkg/render"
+	"github.com/openshift/cluster-network-operator/pkg/util/k8s"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// defaultMultusAdmissionControllerTokenMountPath is where the hosted cluster token and kubeconfig
+// are shared between the admission controller containers, in HyperShift
+const defaultMultusAdmissionControllerTokenMountPath = "/var/run/secrets/hosted_cluster"
+
+const (
+	// defaultMultusAdmissionControllerTokenMinterRequestTimeout bounds the HyperShift token minter
+	// API requests, so that a slow API server doesn't hang the token rotation
+	defaultMultusAdmissionControllerTokenMinterRequestTimeout = 30 * time.Second
+	// multusAdmissionControllerMaxTokenMinterRequestTimeout is the longest token-minter-request-timeout
+	multusAdmissionControllerMaxTokenMinterRequestTimeout = 5 * time.Minute
+)
+
+// multusAdmissionControllerTopologyModeAuto is the service.kubernetes.io/topology-mode value
+// enabling topology aware routing of the webhook Service
+const multusAdmissionControllerTopologyModeAuto = "Auto"
+
+// multusAdmissionControllerReservedMountPaths are mounted by the rendered admission controller
+// container, so the audit log directory can't overlap them
+var multusAdmissionControllerReservedMountPaths = []string{
+	"/etc/webhook",
+	"/hosted-ca",
+	"/var/run/secrets",
+}
+
+// Default readiness and liveness probe timings of the multus admission controller
+const (
+	defaultMultusAdmissionControllerProbeInitialDelaySeconds = 10
+	defaultMultusAdmissionControllerProbePeriodSeconds       = 10
+	defaultMultusAdmissionControllerProbeTimeoutSeconds      = 5
+	defaultMultusAdmissionControllerProbeFailureThreshold    = 3
+)
+
+// Default startup probe timings of the multus admission controller, a 5 minutes budget for the
+// first start, image pull excluded, before the liveness probe kicks in
+const (
+	defaultMultusAdmissionControllerStartupProbePeriodSeconds    = 10
+	defaultMultusAdmissionControllerStartupProbeFailureThreshold = 30
+)
+
+// Default bounds and target of the multus admission controller HorizontalPodAutoscaler. The
+// minimum replicas default to the static replica count of the topology.
+const (
+	defaultMultusAdmissionControllerAutoscalingMaxReplicas    = 6
+	defaultMultusAdmissionControllerAutoscalingCPUUtilization = 80
+)
+
+// Default ephemeral storage request and limit of the admission controller container. The limit
+// evicts a controller filling the node with its logs before the node runs out of disk.
+var (
+	defaultMultusAdmissionControllerEphemeralStorageRequest = resource.MustParse("50Mi")
+	defaultMultusAdmissionControllerEphemeralStorageLimit   = resource.MustParse("1Gi")
+
+	// defaultMultusAdmissionControllerScratchVolumeSizeLimit bounds the emptyDir scratch volumes,
+	// so that a runaway writer is evicted before it fills the node
+	defaultMultusAdmissionControllerScratchVolumeSizeLimit = resource.MustParse("100Mi")
+)
+
+// defaultMultusAdmissionControllerPreStopSleepSeconds is how long a terminating admission controller
+// keeps serving, for its endpoint removal to reach the API servers before it stops
+const defaultMultusAdmissionControllerPreStopSleepSeconds = 5
+
+// Health endpoints served by the multus admission controller image. The liveness endpoint goes
+// through the admission handler, so it fails when the handler stalls, while readiness only
+// reports that the server is up.
+const (
+	multusAdmissionControllerReadinessPath = "/readyz"
+	multusAdmissionControllerLivenessPath  = "/livez"
+)
+
+// defaultMultusAdmissionControllerServingCertSecret is the webhook serving certificate Secret
+// generated by the service CA operator
+const defaultMultusAdmissionControllerServingCertSecret = "multus-admission-controller-secret"
+
+// defaultMultusAdmissionControllerServiceCAKey is the key of the management cluster service CA
+// bundle in the openshift-service-ca.crt ConfigMap
+const defaultMultusAdmissionControllerServiceCAKey = "service-ca.crt"
+
+// defaultMultusAdmissionControllerRevisionHistoryLimit is how many old ReplicaSets of the admission
+// controller Deployment are kept for rollback
+const defaultMultusAdmissionControllerRevisionHistoryLimit = 2
+
+// defaultMultusAdmissionControllerProgressDeadlineSeconds is how long a rollout of the admission
+// controller Deployment may make no progress before it is reported as failed, the API default
+const defaultMultusAdmissionControllerProgressDeadlineSeconds = 600
+
+// multusAdmissionControllerRoleAggregationLabel selects the ClusterRoles aggregated into the
+// multus admission controller permissions, when role aggregation is enabled
+const multusAdmissionControllerRoleAggregationLabel = "rbac.multus.openshift.io/aggregate-to-multus-admission-controller"
+
+// The NetworkAttachmentDefinition resource granted by the NAD reader role
+const (
+	multusAdmissionControllerNADGroupVersion = "k8s.cni.cncf.io/v1"
+	multusAdmissionControllerNADResource     = "network-attachment-definitions"
+)
+
+// The NetworkAttachmentDefinition API group, and the version the webhook rules target until the
+// cluster serves the CRD
+const (
+	multusAdmissionControllerNADGroup          = "k8s.cni.cncf.io"
+	defaultMultusAdmissionControllerNADVersion = "v1"
+)
+
+// multusAdmissionControllerLivenessFailureMultiplier is how many times the readiness failure
+// threshold the liveness probe tolerates, so that a slow handler is taken out of the service
+// well before it is restarted
+const multusAdmissionControllerLivenessFailureMultiplier = 3
+
+// Supported namespace selector strategies of the multus admission controller webhook
+const (
+	// multusAdmissionControllerNamespaceSelectorNames excludes the ignored namespaces from the
+	// webhook by name
+	multusAdmissionControllerNamespaceSelectorNames = "Names"
+	// multusAdmissionControllerNamespaceSelectorLabels excludes the namespaces labeled ignored,
+	// which keeps the webhook configuration small with many ignored namespaces
+	multusAdmissionControllerNamespaceSelectorLabels = "Labels"
+)
+
+// multusAdmissionControllerIgnoredLabel is set by the operator on the ignored namespaces, with the
+// Labels namespace selector strategy
+const multusAdmissionControllerIgnoredLabel = "network.operator.openshift.io/multus-admission-controller-ignored"
+
+// Supported webhook registration modes of the multus admission controller
+const (
+	// multusAdmissionControllerWebhookRegistrationImmediate applies the webhook configuration with
+	// the rest of the objects
+	multusAdmissionControllerWebhookRegistrationImmediate = "immediate"
+	// multusAdmissionControllerWebhookRegistrationAfterReady holds back the creation of the
+	// webhook configuration until the controller endpoints are ready
+	multusAdmissionControllerWebhookRegistrationAfterReady = "after-ready"
+)
+
+// AdmissionReview versions the multus admission controller webhook can be called with
+const (
+	multusAdmissionControllerAdmissionReviewV1      = "v1"
+	multusAdmissionControllerAdmissionReviewV1beta1 = "v1beta1"
+)
+
+// multusAdmissionControllerAdmissionReviewV1MinMinor is the first Kubernetes 1.x minor version
+// whose API server sends v1 AdmissionReviews
+const multusAdmissionControllerAdmissionReviewV1MinMinor = 16
+
+// Supported output formats of the multus admission controller render
+const (
+	// multusAdmissionControllerOutputFormatRaw renders the objects themselves
+	multusAdmissionControllerOutputFormatRaw = "Raw"
+	// multusAdmissionControllerOutputFormatTemplate wraps the objects in an OpenShift Template,
+	// for deploying them with oc process
+	multusAdmissionControllerOutputFormatTemplate = "Template"
+)
+
+// Supported update strategies of the multus admission controller webhook configuration
+const (
+	// multusAdmissionControllerWebhookUpdateApply applies the webhook configuration like any
+	// other object
+	multusAdmissionControllerWebhookUpdateApply = "Apply"
+	// multusAdmissionControllerWebhookUpdateReplaceOnRulesChange replaces the webhook
+	// configuration when its rules change, so no stale rule is merged into the new ones
+	multusAdmissionControllerWebhookUpdateReplaceOnRulesChange = "ReplaceOnRulesChange"
+)
+
+// Supported sources of the multus admission controller pod runAsUser
+const (
+	// multusAdmissionControllerRunAsUserStatic runs the pod as the static user of the render,
+	// or lets the SCC assign it in HyperShift
+	multusAdmissionControllerRunAsUserStatic = "Static"
+	// multusAdmissionControllerRunAsUserNamespaceRange runs the pod as the first UID of the
+	// range the SCC allocated to the admission controller namespace
+	multusAdmissionControllerRunAsUserNamespaceRange = "NamespaceRange"
+)
+
+// multusAdmissionControllerUIDRangeAnnotation is the namespace annotation with the UID range
+// allocated to the namespace, as <first UID>/<size>
+const multusAdmissionControllerUIDRangeAnnotation = "openshift.io/sa.scc.uid-range"
+
+// Supported serving certificate reload strategies of the multus admission controller
+const (
+	// multusAdmissionControllerCertReloadPodRestart rolls out the pods when the serving
+	// certificate (or the CA that signs it) changes, through a hash in the pod template
+	multusAdmissionControllerCertReloadPodRestart = "PodRestart"
+	// multusAdmissionControllerCertReloadFileWatch has the webhook watch the mounted serving
+	// certificate files and reload them in place
+	multusAdmissionControllerCertReloadFileWatch = "FileWatch"
+)
+
+// Ports and paths of the multus admission controller
+const (
+	// multusAdmissionControllerWebhookPort is where the webhook serves admission requests
+	multusAdmissionControllerWebhookPort = 6443
+	// multusAdmissionControllerMetricsListenPort is where the webhook serves metrics
+	multusAdmissionControllerMetricsListenPort = 9091
+	// defaultMultusAdmissionControllerMetricsPort is where metrics are scraped from
+	defaultMultusAdmissionControllerMetricsPort = 8443
+	// defaultMultusAdmissionControllerMetricsPath is the path metrics are scraped from
+	defaultMultusAdmissionControllerMetricsPath = "/metrics"
+)
+
+// defaultMultusAdmissionControllerServiceAccountName is the ServiceAccount the admission controller runs as
+const defaultMultusAdmissionControllerServiceAccountName = "multus-ac"
+
+// Supported metrics authentication modes of the multus admission controller
+const (
+	// multusAdmissionControllerMetricsAuthKubeRBACProxy serves metrics through a kube-rbac-proxy sidecar
+	multusAdmissionControllerMetricsAuthKubeRBACProxy = "kube-rbac-proxy"
+	// multusAdmissionControllerMetricsAuthNone serves metrics without authentication, relying
+	// on network policy to protect the endpoint
+	multusAdmissionControllerMetricsAuthNone = "none"
+)
+
+// Supported policies for the HyperShift admission controller namespace
+const (
+	// multusAdmissionControllerNamespaceRequireExists fails the render if the namespace doesn't exist
+	multusAdmissionControllerNamespaceRequireExists = "require-exists"
+	// multusAdmissionControllerNamespaceRender renders the namespace along with the admission controller
+	multusAdmissionControllerNamespaceRender = "render"
+
+	// maxMultusAdmissionControllerSessionAffinitySeconds is the Kubernetes limit
+	// on the ClientIP session affinity timeout
+	maxMultusAdmissionControllerSessionAffinitySeconds = 86400
+
+	// maxMultusAdmissionControllerDNSNameservers is the Kubernetes limit on the
+	// number of pod dnsConfig nameservers
+	maxMultusAdmissionControllerDNSNameservers = 3
+)
+
+// defaultMultusAdmissionControllerRollingUpdate is the Kubernetes default maxUnavailable and
+// maxSurge, used for whichever of the two isn't configured
+var defaultMultusAdmissionControllerRollingUpdate = intstr.FromString("25%")
+
+// maxMultusAdmissionControllerObjectSize is the default etcd request size limit, which no
+// rendered object may exceed
+const maxMultusAdmissionControllerObjectSize = 1536 * 1024
+
+// multusAdmissionControllerOperatorAnnotationDomains are the annotation domains of the operator,
+// which the backup annotations can't use
+var multusAdmissionControllerOperatorAnnotationDomains = []string{"network.operator.openshift.io", "networkoperator.openshift.io"}
+
+// multusAdmissionControllerReservedEnvVars are the environment variables of the webhook container
+// the render sets, or may set in the future, which extra-env can't override: the hosted cluster
+// kubeconfig with the token path, the API server address, TLS settings and the IPAM hints
+var multusAdmissionControllerReservedEnvVars = sets.New[string](
+	"KUBECONFIG",
+	"KUBERNETES_SERVICE_HOST",
+	"KUBERNETES_SERVICE_PORT",
+	"SSL_CERT_FILE",
+	"SSL_CERT_DIR",
+	"SERVICE_NETWORK_CIDRS",
+	"CLUSTER_NETWORK_CIDRS",
+	"WHEREABOUTS_ENABLED",
+)
+
+// defaultMultusAdmissionControllerRunAsUser is the user the pod runs as outside of HyperShift,
+// nobody
+const defaultMultusAdmissionControllerRunAsUser = 65534
+
+// maxMultusAdmissionControllerWebhookWorkers bounds the webhook worker count, well above what the
+// webhook request rate needs
+const maxMultusAdmissionControllerWebhookWorkers = 64
+
+// multusAdmissionControllerRevisionLabel selects the admission controller pods of a revision,
+// when the Deployment is rendered per revision
+const multusAdmissionControllerRevisionLabel = "network.operator.openshift.io/multus-admission-controller-revision"
+
+// multusAdmissionControllerIgnoredNamespacesHashAnnotation is a hash of the namespaces ignored by
+// the admission controller, set on the webhook configuration with ignored-namespaces-hash
+const multusAdmissionControllerIgnoredNamespacesHashAnnotation = "network.operator.openshift.io/ignored-namespaces-hash"
+
+// defaultKubeRBACProxyImage is the kube-rbac-proxy image used when KUBE_RBAC_PROXY_IMAGE isn't
+// set and no fallback is configured. It is empty unless set at build time, with
+// -ldflags "-X github.com/openshift/cluster-network-operator/pkg/network.defaultKubeRBACProxyImage=<image>"
+var defaultKubeRBACProxyImage = ""
+
+// multusAdmissionControllerImageDigestRegexp matches the image digests of the image-digests mapping
+var multusAdmissionControllerImageDigestRegexp = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// multusAdmissionControllerEnvVars are the environment variables consumed by the multus
+// admission controller render, including the HyperShift configuration read by the platform package
+var multusAdmissionControllerEnvVars = []string{
+	"RELEASE_VERSION",
+	"MULTUS_ADMISSION_CONTROLLER_IMAGE",
+	"KUBE_RBAC_PROXY_IMAGE",
+	"RHOBS_MONITORING",
+	"CLI_IMAGE",
+	"TOKEN_MINTER_IMAGE",
+	"TOKEN_AUDIENCE",
+	"HYPERSHIFT",
+	"HOSTED_CLUSTER_NAMESPACE",
+	"RUN_AS_USER",
+	"OPENSHIFT_RELEASE_IMAGE",
+}
+
+// EnvVarStatus reports whether an environment variable is set, without its value
+type EnvVarStatus struct {
+	Name    string
+	Present bool
+}
+
+// RequiredEnvVars returns the environment variables consumed by the multus admission
+// controller render and whether each of them is set, for must-gather and support tooling.
+func RequiredEnvVars() []EnvVarStatus {
+	out := make([]EnvVarStatus, 0, len(multusAdmissionControllerEnvVars))
+	for _, name := range multusAdmissionControllerEnvVars {
+		_, present := os.LookupEnv(name)
+		out = append(out, EnvVarStatus{Name: name, Present: present})
+	}
+	return out
+}
+
 // ignoredNamespaces contains the comma separated namespace list that should be ignored
-// to watch by multus admission controller. This only initialized first invocation.
+// to watch by multus admission controller. This is discovered until a discovery succeeds, then
+// only rediscovered with ignored-namespaces-refresh-interval.
 var ignoredNamespaces string
 
-// getOpenshiftNamespaces collect openshift related namespaces, as comma separate list
+// ignoredNamespacesUpdated is when ignoredNamespaces was last discovered, zero until a discovery
+// succeeds
+var ignoredNamespacesUpdated time.Time
+
+const (
+	// defaultMultusAdmissionControllerIgnoredNamespacesGracePeriod is how long the last
+	// discovered ignored namespaces are kept while discovery fails
+	defaultMultusAdmissionControllerIgnoredNamespacesGracePeriod = 30 * time.Minute
+	// multusAdmissionControllerMinReconcileInterval and multusAdmissionControllerMaxReconcileInterval
+	// bound the reconcile-interval hint; below the resync period the hint has no effect and above
+	// an hour changes not signaled by a watch event would go unreconciled for too long
+	multusAdmissionControllerMinReconcileInterval = 3 * time.Minute
+	multusAdmissionControllerMaxReconcileInterval = time.Hour
+)
+
+// multusAdmissionControllerOptOutLabel, set to multusAdmissionControllerOptOutValue, opts a
+// namespace out of the multus admission controller validation
+const (
+	multusAdmissionControllerOptOutLabel = "multus.openshift.io/admission"
+	multusAdmissionControllerOptOutValue = "ignore"
+)
+
+// staticIgnoredNamespaces are always ignored by multus admission controller, in addition
+// to the discovered openshift namespaces.
+var staticIgnoredNamespaces = []string{"openshift-etcd", "openshift-console", "openshift-ingress-canary"}
+
+// multusAdmissionControllerIgnoredNamespaces reports the number of namespaces ignored by
+// multus admission controller, as of the last render.
+var multusAdmissionControllerIgnoredNamespaces = metrics.NewGauge(&metrics.GaugeOpts{
+	Name: "cno_multus_admission_controller_ignored_namespaces",
+	Help: "Number of namespaces ignored by the multus admission controller webhook as of the last render.",
+})
+
+func init() {
+	legacyregistry.MustRegister(multusAdmissionControllerIgnoredNamespaces)
+}
+
+// getIgnoredNamespaces returns the full list of namespaces ignored by multus admission controller
+func getIgnoredNamespaces() []string {
+	namespaces := append([]string{}, staticIgnoredNamespaces...)
+	if ignoredNamespaces != "" {
+		namespaces = append(namespaces, strings.Split(ignoredNamespaces, ",")...)
+	}
+	return namespaces
+}
+
+// updateIgnoredNamespaces discovers the ignored namespaces, unless a discovery already succeeded,
+// even with no namespaces, less than refreshInterval ago, or at all without a refreshInterval. When
+// a rediscovery fails, the last discovered namespaces are kept for the grace period, so that an API
+// blip doesn't expose the openshift namespaces to the webhook.
+func updateIgnoredNamespaces(client cnoclient.Client, refreshInterval, gracePeriod time.Duration, now time.Time) {
+	age := now.Sub(ignoredNamespacesUpdated)
+	if !ignoredNamespacesUpdated.IsZero() && (refreshInterval == 0 || age < refreshInterval) {
+		return
+	}
+	namespaces, err := getOpenshiftNamespaces(client)
+	if err == nil {
+		ignoredNamespaces = namespaces
+		ignoredNamespacesUpdated = now
+		return
+	}
+	if ignoredNamespacesUpdated.IsZero() {
+		klog.Warningf("failed to get openshift namespaces: %+v", err)
+		return
+	}
+	if age < gracePeriod {
+		klog.Warningf("failed to get openshift namespaces, keeping the ones discovered %s ago: %+v", age.Round(time.Second), err)
+		return
+	}
+	klog.Warningf("failed to get openshift namespaces, dropping the ones discovered %s ago after the %s grace period: %+v",
+		age.Round(time.Second), gracePeriod, err)
+	ignoredNamespaces = ""
+}
+
+// multusAdmissionControllerWebhookNamespaceSelector returns the webhook namespaceSelector
+// excluding the ignored namespaces with strategy, or nil to leave them to the admission
+// controller -ignore-namespaces argument only
+func multusAdmissionControllerWebhookNamespaceSelector(strategy string, namespaces []string) *metav1.LabelSelector {
+	switch strategy {
+	case multusAdmissionControllerNamespaceSelectorNames:
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   sets.List(sets.New(namespaces...)),
+		}}}
+	case multusAdmissionControllerNamespaceSelectorLabels:
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: multusAdmissionControllerIgnoredLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+			// the opted out namespaces are excluded before they are discovered and labeled
+			{Key: multusAdmissionControllerOptOutLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{multusAdmissionControllerOptOutValue}},
+		}}
+	}
+	return nil
+}
+
+// LabelMultusAdmissionControllerIgnoredNamespaces sets multusAdmissionControllerIgnoredLabel on
+// the namespaces ignored as of the last render when the webhook uses the Labels namespace
+// selector strategy, and removes it from the namespaces that aren't ignored anymore, or from all of
+// them with another strategy. It runs once the rendered objects are applied; meanwhile the
+// admission controller still ignores the namespaces by name.
+func LabelMultusAdmissionControllerIgnoredNamespaces(ctx context.Context, client cnoclient.Client, conf *bootstrap.MultusAdmissionControllerBootstrapResult) error {
+	nsClient := client.Default().Kubernetes().CoreV1().Namespaces()
+	labeled, err := nsClient.List(ctx, metav1.ListOptions{LabelSelector: multusAdmissionControllerIgnoredLabel})
+	if err != nil {
+		return fmt.Errorf("failed to get the namespaces labeled %s: %w", multusAdmissionControllerIgnoredLabel, err)
+	}
+	ignored := sets.New[string]()
+	if conf.NamespaceSelectorStrategy == multusAdmissionControllerNamespaceSelectorLabels {
+		ignored.Insert(getIgnoredNamespaces()...)
+	}
+	stale := sets.New[string]()
+	for _, ns := range labeled.Items {
+		if ignored.Has(ns.Name) {
+			ignored.Delete(ns.Name)
+		} else {
+			stale.Insert(ns.Name)
+		}
+	}
+
+	var errs []error
+	patch := func(name string, value interface{}) {
+		b, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{multusAdmissionControllerIgnoredLabel: value},
+			},
+		})
+		if err == nil {
+			_, err = nsClient.Patch(ctx, name, types.MergePatchType, b, metav1.PatchOptions{})
+		}
+		// the static ignored namespaces don't exist on every cluster
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to update the %s label of namespace %s: %w", multusAdmissionControllerIgnoredLabel, name, err))
+		}
+	}
+	for _, name := range sets.List(ignored) {
+		patch(name, "true")
+	}
+	for _, name := range sets.List(stale) {
+		patch(name, nil)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// getOpenshiftNamespaces collect openshift related namespaces and the namespaces that opted out of
+// the webhook, as comma separate list
 func getOpenshiftNamespaces(client cnoclient.Client) (string, error) {
 	namespaces := []string{}
 
@@ -42,71 +532,2357 @@ func getOpenshiftNamespaces(client cnoclient.Client) (string, error) {
 	for _, ns := range nsList.Items {
 		namespaces = append(namespaces, ns.Name)
 	}
+
+	optOutList, err := client.Default().Kubernetes().CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: multusAdmissionControllerOptOutLabel,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get opted out namespaces to render multus admission controller manifests")
+	}
+
+	seen := sets.New(namespaces...)
+	for _, ns := range optOutList.Items {
+		if value := ns.Labels[multusAdmissionControllerOptOutLabel]; value != multusAdmissionControllerOptOutValue {
+			klog.Warningf("Namespace %s has an invalid %s label %q, only %q opts out of the multus admission controller",
+				ns.Name, multusAdmissionControllerOptOutLabel, value, multusAdmissionControllerOptOutValue)
+			continue
+		}
+		if !seen.Has(ns.Name) {
+			seen.Insert(ns.Name)
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
 	return strings.Join(namespaces, ","), nil
 }
 
-// renderMultusAdmissonControllerConfig returns the manifests of Multus Admisson Controller
-func renderMultusAdmissonControllerConfig(manifestDir string, externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client) ([]*uns.Unstructured, error) {
-	objs := []*uns.Unstructured{}
-	var err error
+// getManagementServiceCA returns the openshift-service-ca.crt ConfigMap of the hosted control plane
+// namespace. If it doesn't exist, the most current copy in the management cluster is used instead.
+func getManagementServiceCA(mgmtClient cnoclient.ClusterClient, namespace, key string) (*corev1.ConfigMap, error) {
+	serviceCA := &corev1.ConfigMap{}
+	err := mgmtClient.CRClient().Get(
+		context.TODO(), types.NamespacedName{Namespace: namespace, Name: "openshift-service-ca.crt"}, serviceCA)
+	if err == nil {
+		return serviceCA, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, newManagementClusterError(fmt.Errorf("failed to get managments clusters service CA: %w", err))
+	}
 
-	replicas := getMultusAdmissionControllerReplicas(bootstrapResult)
-	if ignoredNamespaces == "" {
-		ignoredNamespaces, err = getOpenshiftNamespaces(client)
+	cmList := &corev1.ConfigMapList{}
+	if err := mgmtClient.CRClient().List(context.TODO(), cmList); err != nil {
+		return nil, newManagementClusterError(fmt.Errorf("failed to list managments clusters service CAs: %w", err))
+	}
+	candidates := []corev1.ConfigMap{}
+	for _, cm := range cmList.Items {
+		if cm.Name == "openshift-service-ca.crt" {
+			candidates = append(candidates, cm)
+		}
+	}
+	serviceCA = selectServiceCA(candidates, key)
+	if serviceCA == nil {
+		return nil, fmt.Errorf("failed to get managments clusters service CA: no openshift-service-ca.crt configmap found")
+	}
+	klog.Infof("service CA %s/openshift-service-ca.crt not found, using %s/%s", namespace, serviceCA.Namespace, serviceCA.Name)
+	return serviceCA, nil
+}
+
+// selectServiceCA returns the most current of the candidate service CA ConfigMaps: the one with
+// the most recently issued certificate, then the one with the most certificates, since a CA
+// rotation appends the new CA to the bundle, then the most recently created one
+func selectServiceCA(candidates []corev1.ConfigMap, key string) *corev1.ConfigMap {
+	var selected *corev1.ConfigMap
+	var selectedNewest time.Time
+	var selectedCount int
+	for i := range candidates {
+		cm := &candidates[i]
+		newest, count := parseServiceCABundle(cm.Data[key])
+		if selected == nil ||
+			newest.After(selectedNewest) ||
+			(newest.Equal(selectedNewest) && count > selectedCount) ||
+			(newest.Equal(selectedNewest) && count == selectedCount && selected.CreationTimestamp.Before(&cm.CreationTimestamp)) {
+			selected, selectedNewest, selectedCount = cm, newest, count
+		}
+	}
+	return selected
+}
+
+// parseServiceCABundle returns the latest NotBefore and the number of the certificates in bundle
+func parseServiceCABundle(bundle string) (time.Time, int) {
+	var newest time.Time
+	count := 0
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
 		if err != nil {
-			klog.Warningf("failed to get openshift namespaces: %+v", err)
+			continue
+		}
+		count++
+		if cert.NotBefore.After(newest) {
+			newest = cert.NotBefore
 		}
 	}
+	return newest, count
+}
 
-	// render the manifests on disk
-	data := render.MakeRenderData()
-	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
-	data.Data["MultusAdmissionControllerImage"] = os.Getenv("MULTUS_ADMISSION_CONTROLLER_IMAGE")
-	data.Data["IgnoredNamespace"] = ignoredNamespaces
-	data.Data["MultusValidatingWebhookName"] = names.MULTUS_VALIDATING_WEBHOOK
-	data.Data["KubeRBACProxyImage"] = os.Getenv("KUBE_RBAC_PROXY_IMAGE")
-	data.Data["ExternalControlPlane"] = externalControlPlane
-	data.Data["Replicas"] = replicas
-	// Hypershift
-	hsc := platform.NewHyperShiftConfig()
-	data.Data["HyperShiftEnabled"] = hsc.Enabled
-	data.Data["ManagementClusterName"] = names.ManagementClusterName
-	data.Data["AdmissionControllerNamespace"] = "openshift-multus"
-	data.Data["RHOBSMonitoring"] = os.Getenv("RHOBS_MONITORING")
-	if hsc.Enabled {
-		data.Data["AdmissionControllerNamespace"] = hsc.Namespace
-		data.Data["KubernetesServiceHost"] = bootstrapResult.Infra.APIServers[bootstrap.APIServerDefaultLocal].Host
-		data.Data["KubernetesServicePort"] = bootstrapResult.Infra.APIServers[bootstrap.APIServerDefaultLocal].Port
-		data.Data["CLIImage"] = os.Getenv("CLI_IMAGE")
-		data.Data["TokenMinterImage"] = os.Getenv("TOKEN_MINTER_IMAGE")
-		data.Data["TokenAudience"] = os.Getenv("TOKEN_AUDIENCE")
-		data.Data["RunAsUser"] = hsc.RunAsUser
+// FeatureGateSet is the set of feature gates enabled in the cluster, so that the render can
+// branch on gates rather than on versions
+type FeatureGateSet map[string]bool
+
+// NewFeatureGateSet returns the set of enabled gates of featureGates
+func NewFeatureGateSet(featureGates featuregates.FeatureGate) FeatureGateSet {
+	set := FeatureGateSet{}
+	if featureGates == nil {
+		return set
+	}
+	for _, name := range featureGates.KnownFeatures() {
+		if featureGates.Enabled(name) {
+			set[string(name)] = true
+		}
+	}
+	return set
+}
+
+// Enabled returns true if the named gate is enabled. Unlike featuregates.FeatureGate, gates
+// unknown to the cluster are reported disabled
+func (s FeatureGateSet) Enabled(name configv1.FeatureGateName) bool {
+	return s[string(name)]
+}
+
+// validateMultusAdmissionControllerBootstrap checks that the bootstrap result has the fields
+// needed to render the multus admission controller in the current mode
+func validateMultusAdmissionControllerBootstrap(bootstrapResult *bootstrap.BootstrapResult) error {
+	if bootstrapResult == nil {
+		return fmt.Errorf("cannot render multus admission controller: bootstrap result is missing")
+	}
+	hsc := bootstrapResult.MultusAdmissionController.HyperShiftConfig
+	if hsc == nil || !hsc.Enabled {
+		return nil
+	}
+	if hsc.Namespace == "" {
+		return fmt.Errorf("cannot render multus admission controller: bootstrap result is missing MultusAdmissionController.HyperShiftConfig.Namespace")
+	}
+	apiServer := multusAdmissionControllerTokenMinterAPIServer(&bootstrapResult.MultusAdmissionController)
+	if err := bootstrap.ValidateAPIServers(bootstrapResult, []string{apiServer}); err != nil {
+		if apiServer != bootstrap.APIServerDefaultLocal {
+			return fmt.Errorf("cannot render multus admission controller: %w, selected by token-minter-api-server", err)
+		}
+		return fmt.Errorf("cannot render multus admission controller: %w", err)
+	}
+	if bootstrapResult.Infra.HostedControlPlane == nil {
+		return fmt.Errorf("cannot render multus admission controller: bootstrap result is missing Infra.HostedControlPlane")
+	}
+	return nil
+}
+
+// multusAdmissionControllerTokenMinterAPIServer returns the Infra.APIServers key the HyperShift token
+// minter reaches the hosted cluster API with
+func multusAdmissionControllerTokenMinterAPIServer(conf *bootstrap.MultusAdmissionControllerBootstrapResult) string {
+	if conf.TokenMinterAPIServer != "" {
+		return conf.TokenMinterAPIServer
+	}
+	return bootstrap.APIServerDefaultLocal
+}
+
+// validateMultusAdmissionControllerTopology checks that HyperShift, where the admission controller
+// runs in the management cluster, comes with an external control plane. An external control plane
+// without HyperShift is a supported topology, where the admission controller runs in the cluster
+// like with any other one.
+func validateMultusAdmissionControllerTopology(externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult) error {
+	hsc := bootstrapResult.MultusAdmissionController.HyperShiftConfig
+	topology := bootstrapResult.Infra.ControlPlaneTopology
+	if topology != "" && (topology == configv1.ExternalTopologyMode) != externalControlPlane {
+		klog.Warningf("multus admission controller external control plane is %t but the control plane topology is %s",
+			externalControlPlane, topology)
+	}
+	if hsc != nil && hsc.Enabled && (!externalControlPlane || (topology != "" && topology != configv1.ExternalTopologyMode)) {
+		return fmt.Errorf("cannot render multus admission controller: HyperShift is enabled but the control plane is not external "+
+			"(external control plane %t, control plane topology %q)", externalControlPlane, topology)
+	}
+	return nil
+}
+
+// normalizeImageReference trims an image reference and lowercases its registry host, which is
+// case insensitive. It fails on references with no repository, or with blanks inside. An empty
+// reference is returned as is.
+func normalizeImageReference(image string) (string, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return "", nil
+	}
+	if strings.ContainsAny(image, " \t\r\n") {
+		return "", fmt.Errorf("image reference %q contains whitespace", image)
+	}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	// like the container runtimes, the first component is a registry host if it looks like one
+	host, repository := "", name
+	if i := strings.Index(name, "/"); i >= 0 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		host, repository = name[:i], name[i+1:]
+	}
+	if repository == "" || strings.HasPrefix(repository, "/") || strings.HasSuffix(repository, "/") || strings.Contains(repository, "//") {
+		return "", fmt.Errorf("image reference %q has no repository", image)
+	}
+	return strings.ToLower(host) + image[len(host):], nil
+}
+
+// getMultusAdmissionControllerImage returns the normalized image reference of the envVar
+// environment variable
+func getMultusAdmissionControllerImage(envVar string) (string, error) {
+	image, err := normalizeImageReference(os.Getenv(envVar))
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", envVar, err)
+	}
+	return image, nil
+}
+
+// getMultusAdmissionControllerKubeRBACProxyImage returns the kube-rbac-proxy image from the
+// KUBE_RBAC_PROXY_IMAGE environment variable. Builds that don't inject it, such as development
+// builds, fall back to the configured image, then to the one set at build time.
+func getMultusAdmissionControllerKubeRBACProxyImage(fallback string) (string, error) {
+	image, err := getMultusAdmissionControllerImage("KUBE_RBAC_PROXY_IMAGE")
+	if err != nil || image != "" {
+		return image, err
+	}
+	source := "kube-rbac-proxy-fallback-image"
+	if fallback == "" {
+		fallback, source = defaultKubeRBACProxyImage, "the build default"
+	}
+	if image, err = normalizeImageReference(fallback); err != nil {
+		return "", fmt.Errorf("invalid fallback kube-rbac-proxy image from %s: %w", source, err)
+	}
+	if image != "" {
+		klog.Infof("KUBE_RBAC_PROXY_IMAGE is not set, using the kube-rbac-proxy image %s from %s", image, source)
+	}
+	return image, nil
+}
+
+// resolveImageDigest returns the digest form of an image reference, if digests has the digest of
+// the reference. References already pinned by digest, or with no known digest, are returned as is.
+func resolveImageDigest(image string, digests map[string]string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	digest, ok := digests[image]
+	if !ok {
+		return image
+	}
+	// the tag follows the last colon after the last slash, the colons before are a registry port
+	repository := image
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository = image[:i]
+	}
+	return repository + "@" + digest
+}
 
-		// Get serving CA from the management cluster since the service resides there
-		serviceCA := &corev1.ConfigMap{}
-		err := client.ClientFor(names.ManagementClusterName).CRClient().Get(
-			context.TODO(), types.NamespacedName{Namespace: hsc.Namespace, Name: "openshift-service-ca.crt"}, serviceCA)
+// getMultusAdmissionControllerServingCertSecret returns the externally provisioned webhook serving
+// certificate Secret of clusterName, checking that it has a certificate and a key. In HyperShift the
+// Secret is in the management cluster, with the admission controller.
+func getMultusAdmissionControllerServingCertSecret(client cnoclient.Client, clusterName, namespace, name string) (*corev1.Secret, error) {
+	secretClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	err = secretClient.CRClient().Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("serving-cert-secret %s/%s does not exist", namespace, name)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to get serving-cert-secret %s/%s: %w", namespace, name, err)
+		if clusterName != "" {
+			return nil, newManagementClusterError(err)
+		}
+		return nil, err
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("serving-cert-secret %s/%s is missing the %s key", namespace, name, key)
+		}
+	}
+	return secret, nil
+}
+
+// multusAdmissionControllerServerVersion caches the API server version the admission review
+// versions are derived from, nil until a discovery succeeds. The operator is redeployed on
+// upgrades, so it doesn't need to be rediscovered.
+var multusAdmissionControllerServerVersion *version.Info
+
+// getMultusAdmissionControllerAdmissionReviewVersions returns the AdmissionReview versions the
+// webhook advertises: only v1 when the API server sends v1 AdmissionReviews, v1beta1 otherwise,
+// or the given override, provided the API server supports at least one of its versions. An API
+// server that doesn't report its version is assumed to be current, as is one whose version
+// can't be discovered, with a warning.
+func getMultusAdmissionControllerAdmissionReviewVersions(client cnoclient.Client, override []string) ([]string, error) {
+	info := multusAdmissionControllerServerVersion
+	if info == nil {
+		var err error
+		info, err = client.Default().Kubernetes().Discovery().ServerVersion()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get managments clusters service CA: %v", err)
+			if len(override) > 0 {
+				klog.Warningf("Failed to discover the API server version, using the admission-review-versions %v unchecked: %v", override, err)
+				return override, nil
+			}
+			klog.Warningf("Failed to discover the API server version, assuming it supports %s admission reviews: %v",
+				multusAdmissionControllerAdmissionReviewV1, err)
+			return []string{multusAdmissionControllerAdmissionReviewV1}, nil
 		}
-		ca, exists := serviceCA.Data["service-ca.crt"]
-		if !exists {
-			return nil, fmt.Errorf("(%s) %s/%s missing 'service-ca.crt' key", serviceCA.GroupVersionKind(), serviceCA.Namespace, serviceCA.Name)
+		multusAdmissionControllerServerVersion = info
+	}
+	supported := []string{multusAdmissionControllerAdmissionReviewV1, multusAdmissionControllerAdmissionReviewV1beta1}
+	major, majorErr := strconv.Atoi(info.Major)
+	// minor versions may have a "+" suffix
+	minor, minorErr := strconv.Atoi(strings.TrimSuffix(info.Minor, "+"))
+	if majorErr != nil || minorErr != nil {
+		klog.V(2).Infof("API server version %q/%q is not numeric, assuming it supports %v admission reviews", info.Major, info.Minor, supported)
+	} else if major == 1 && minor < multusAdmissionControllerAdmissionReviewV1MinMinor {
+		supported = []string{multusAdmissionControllerAdmissionReviewV1beta1}
+	}
+
+	if len(override) == 0 {
+		return supported[:1], nil
+	}
+	for _, version := range override {
+		for _, s := range supported {
+			if version == s {
+				return override, nil
+			}
 		}
+	}
+	return nil, fmt.Errorf("invalid admission-review-versions %v: the API server %s.%s only supports %v", override, info.Major, info.Minor, supported)
+}
 
-		data.Data["ManagementServiceCABundle"] = base64.URLEncoding.EncodeToString([]byte(ca))
+// validateMultusAdmissionControllerWebhookRuleResources checks through discovery that the
+// resources of the extra webhook rules are served by the cluster
+func validateMultusAdmissionControllerWebhookRuleResources(rules []admissionregistrationv1.RuleWithOperations, client cnoclient.Client) error {
+	served := map[string]sets.Set[string]{}
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, version := range rule.APIVersions {
+				groupVersion := schema.GroupVersion{Group: group, Version: version}.String()
+				resources, ok := served[groupVersion]
+				if !ok {
+					list, err := client.Default().Kubernetes().Discovery().ServerResourcesForGroupVersion(groupVersion)
+					if err != nil && !apierrors.IsNotFound(err) {
+						return fmt.Errorf("failed to discover the resources of %s for extra-webhook-rules: %w", groupVersion, err)
+					}
+					resources = sets.New[string]()
+					if list != nil {
+						for _, resource := range list.APIResources {
+							resources.Insert(resource.Name)
+						}
+					}
+					served[groupVersion] = resources
+				}
+				for _, resource := range rule.Resources {
+					// subresources are matched by their parent resource
+					if !resources.Has(strings.SplitN(resource, "/", 2)[0]) {
+						return fmt.Errorf("invalid extra-webhook-rules: resource %q of %s is not served by the cluster", resource, groupVersion)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
 
-		data.Data["ClusterIDLabel"] = platform.ClusterIDLabel
-		data.Data["ClusterID"] = bootstrapResult.Infra.HostedControlPlane.Spec.ClusterID
-		data.Data["HCPNodeSelector"] = bootstrapResult.Infra.HostedControlPlane.Spec.NodeSelector
+// multusAdmissionControllerHPAsServed returns whether the cluster serves the autoscaling/v2
+// HorizontalPodAutoscalers the autoscaling option renders
+func multusAdmissionControllerHPAsServed(client cnoclient.Client) (bool, error) {
+	return multusAdmissionControllerResourceServed(client, autoscalingv2.SchemeGroupVersion.String(), "horizontalpodautoscalers", "autoscaling")
+}
 
-		data.Data["ReleaseImage"] = hsc.ReleaseImage
+// multusAdmissionControllerResourceServed returns whether the cluster serves resource of
+// groupVersion, which the config key depends on
+func multusAdmissionControllerResourceServed(client cnoclient.Client, groupVersion, resource, key string) (bool, error) {
+	list, err := client.Default().Kubernetes().Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover the resources of %s for %s: %w", groupVersion, key, err)
+	}
+	for _, apiResource := range list.APIResources {
+		if apiResource.Name == resource {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	manifests, err := render.RenderDir(filepath.Join(manifestDir, "network/multus-admission-controller"), &data)
+// getMultusAdmissionControllerNADVersions returns the NetworkAttachmentDefinition versions the
+// webhook rules match, newest first in the Kubernetes version order, where stable versions come
+// before the prereleases. With the Equivalent match policy, the API server matches
+// writes of any served version to a rule of another, so the rules only need the newest; with
+// Exact, they list every served version. The default version is matched while none is served,
+// or with a warning, when the served versions can't be discovered.
+func getMultusAdmissionControllerNADVersions(client cnoclient.Client, matchPolicy admissionregistrationv1.MatchPolicyType) []string {
+	discovery := client.Default().Kubernetes().Discovery()
+	groups, err := discovery.ServerGroups()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to render multus admission controller manifests")
+		klog.Warningf("Failed to discover the %s versions for the webhook rules, matching %s: %v",
+			multusAdmissionControllerNADGroup, defaultMultusAdmissionControllerNADVersion, err)
+		return []string{defaultMultusAdmissionControllerNADVersion}
 	}
-	objs = append(objs, manifests...)
-	return objs, nil
+	served := []string{}
+	for _, group := range groups.Groups {
+		if group.Name != multusAdmissionControllerNADGroup {
+			continue
+		}
+		for _, groupVersion := range group.Versions {
+			list, err := discovery.ServerResourcesForGroupVersion(groupVersion.GroupVersion)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				klog.Warningf("Failed to discover the resources of %s for the webhook rules, matching %s: %v",
+					groupVersion.GroupVersion, defaultMultusAdmissionControllerNADVersion, err)
+				return []string{defaultMultusAdmissionControllerNADVersion}
+			}
+			for _, resource := range list.APIResources {
+				if resource.Name == multusAdmissionControllerNADResource {
+					served = append(served, groupVersion.Version)
+					break
+				}
+			}
+		}
+	}
+	if len(served) == 0 {
+		return []string{defaultMultusAdmissionControllerNADVersion}
+	}
+	sort.Slice(served, func(i, j int) bool {
+		return version.CompareKubeAwareVersionStrings(served[i], served[j]) > 0
+	})
+	if matchPolicy == admissionregistrationv1.Exact {
+		return served
+	}
+	return served[:1]
+}
+
+// multusAdmissionControllerReportedOverlappingWebhooks is the comma separated list of the overlapping
+// webhooks last reported
+var multusAdmissionControllerReportedOverlappingWebhooks string
+
+// reportMultusAdmissionControllerOverlappingWebhooks reports the webhooks overlapping the admission
+// controller's when they differ from the ones last reported, and returns whether it did, so that
+// the warning isn't repeated on every render
+func reportMultusAdmissionControllerOverlappingWebhooks(overlapping []string) bool {
+	list := strings.Join(overlapping, ", ")
+	if list == multusAdmissionControllerReportedOverlappingWebhooks {
+		return false
+	}
+	multusAdmissionControllerReportedOverlappingWebhooks = list
+	if list == "" {
+		klog.Infof("Webhooks of other ValidatingWebhookConfigurations no longer validate NetworkAttachmentDefinitions")
+	} else {
+		klog.Warningf("Webhooks of other ValidatingWebhookConfigurations also validate NetworkAttachmentDefinitions, "+
+			"so a NetworkAttachmentDefinition may be rejected by either: %s", list)
+	}
+	return true
+}
+
+// getMultusAdmissionControllerOverlappingWebhooks returns the webhooks, as <configuration>/<webhook>,
+// of the ValidatingWebhookConfigurations other than the admission controller's whose rules match
+// the NetworkAttachmentDefinition writes the admission controller validates
+func getMultusAdmissionControllerOverlappingWebhooks(client cnoclient.Client) ([]string, error) {
+	configs := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := client.Default().CRClient().List(context.TODO(), configs); err != nil {
+		return nil, fmt.Errorf("failed to list ValidatingWebhookConfigurations: %w", err)
+	}
+	group, version, _ := strings.Cut(multusAdmissionControllerNADGroupVersion, "/")
+	matches := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == "*" || v == value {
+				return true
+			}
+		}
+		return false
+	}
+	overlapping := []string{}
+	for _, config := range configs.Items {
+		if config.Name == names.MULTUS_VALIDATING_WEBHOOK {
+			continue
+		}
+		for _, webhook := range config.Webhooks {
+			for _, rule := range webhook.Rules {
+				// NetworkAttachmentDefinitions are namespaced
+				if rule.Scope != nil && *rule.Scope == admissionregistrationv1.ClusterScope {
+					continue
+				}
+				operations := make([]string, 0, len(rule.Operations))
+				for _, operation := range rule.Operations {
+					operations = append(operations, string(operation))
+				}
+				resources := make([]string, 0, len(rule.Resources))
+				for _, resource := range rule.Resources {
+					// */* matches all the resources, along with their subresources
+					if resource == "*/*" {
+						resource = "*"
+					}
+					resources = append(resources, resource)
+				}
+				if matches(rule.APIGroups, group) && matches(rule.APIVersions, version) &&
+					matches(resources, multusAdmissionControllerNADResource) &&
+					(matches(operations, string(admissionregistrationv1.Create)) || matches(operations, string(admissionregistrationv1.Update))) {
+					overlapping = append(overlapping, config.Name+"/"+webhook.Name)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(overlapping)
+	return overlapping, nil
+}
+
+// validateMultusAdmissionControllerPorts checks that the ports the admission controller pod listens
+// on are distinct, since a collision only shows as a bind error in the pod logs
+func validateMultusAdmissionControllerPorts(webhookPort, metricsListenPort, metricsPort int) error {
+	ports := []struct {
+		name string
+		port int
+	}{
+		{"webhook port", webhookPort},
+		{"kube-rbac-proxy upstream port", metricsListenPort},
+		{"metrics port (metrics-port)", metricsPort},
+	}
+	for i := range ports {
+		for j := 0; j < i; j++ {
+			if ports[i].port == ports[j].port {
+				return fmt.Errorf("invalid ports: the %s and the %s are both %d", ports[j].name, ports[i].name, ports[i].port)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerProbes checks the probe timing overrides, against the defaults
+// of the ones that aren't overridden
+func validateMultusAdmissionControllerProbes(conf *bootstrap.MultusAdmissionControllerBootstrapResult) error {
+	initialDelay := valueOrDefault(conf.ProbeInitialDelaySeconds, defaultMultusAdmissionControllerProbeInitialDelaySeconds)
+	period := valueOrDefault(conf.ProbePeriodSeconds, defaultMultusAdmissionControllerProbePeriodSeconds)
+	timeout := valueOrDefault(conf.ProbeTimeoutSeconds, defaultMultusAdmissionControllerProbeTimeoutSeconds)
+	failureThreshold := valueOrDefault(conf.ProbeFailureThreshold, defaultMultusAdmissionControllerProbeFailureThreshold)
+	if initialDelay < 0 {
+		return fmt.Errorf("invalid probe-initial-delay-seconds %d: must not be negative", initialDelay)
+	}
+	if period < 1 {
+		return fmt.Errorf("invalid probe-period-seconds %d: must be at least 1", period)
+	}
+	if timeout < 1 {
+		return fmt.Errorf("invalid probe-timeout-seconds %d: must be at least 1", timeout)
+	}
+	if timeout > period {
+		return fmt.Errorf("invalid probe-timeout-seconds %d: must not be greater than probe-period-seconds %d", timeout, period)
+	}
+	if failureThreshold < 1 {
+		return fmt.Errorf("invalid probe-failure-threshold %d: must be at least 1", failureThreshold)
+	}
+	startupPeriod := valueOrDefault(conf.StartupProbePeriodSeconds, defaultMultusAdmissionControllerStartupProbePeriodSeconds)
+	startupFailureThreshold := valueOrDefault(conf.StartupProbeFailureThreshold, defaultMultusAdmissionControllerStartupProbeFailureThreshold)
+	if startupPeriod < 1 {
+		return fmt.Errorf("invalid startup-probe-period-seconds %d: must be at least 1", startupPeriod)
+	}
+	if timeout > startupPeriod {
+		return fmt.Errorf("invalid probe-timeout-seconds %d: must not be greater than startup-probe-period-seconds %d", timeout, startupPeriod)
+	}
+	if startupFailureThreshold < 1 {
+		return fmt.Errorf("invalid startup-probe-failure-threshold %d: must be at least 1", startupFailureThreshold)
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerAutoscaling checks the HorizontalPodAutoscaler options. The
+// replica bounds are checked against each other at render, once the topology default is known.
+func validateMultusAdmissionControllerAutoscaling(conf *bootstrap.MultusAdmissionControllerBootstrapResult) error {
+	options := map[string]*int{
+		"autoscaling-min-replicas":       conf.AutoscalingMinReplicas,
+		"autoscaling-max-replicas":       conf.AutoscalingMaxReplicas,
+		"autoscaling-cpu-utilization":    conf.AutoscalingCPUUtilization,
+		"autoscaling-memory-utilization": conf.AutoscalingMemoryUtilization,
+	}
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := options[key]
+		if value == nil {
+			continue
+		}
+		if !conf.Autoscaling {
+			return fmt.Errorf("%s requires autoscaling", key)
+		}
+		if *value < 1 {
+			return fmt.Errorf("invalid %s %d: must be at least 1", key, *value)
+		}
+	}
+	if conf.Autoscaling && conf.Paused {
+		return fmt.Errorf("autoscaling conflicts with paused, which fixes the replicas to zero")
+	}
+	return nil
+}
+
+// multusAdmissionControllerEphemeralStorage returns the ephemeral storage request and limit of the
+// admission controller container, the defaults of the ones that aren't overridden
+func multusAdmissionControllerEphemeralStorage(conf *bootstrap.MultusAdmissionControllerBootstrapResult) (resource.Quantity, resource.Quantity) {
+	request, limit := defaultMultusAdmissionControllerEphemeralStorageRequest, defaultMultusAdmissionControllerEphemeralStorageLimit
+	if conf.EphemeralStorageRequest != nil {
+		request = *conf.EphemeralStorageRequest
+	}
+	if conf.EphemeralStorageLimit != nil {
+		limit = *conf.EphemeralStorageLimit
+	}
+	return request, limit
+}
+
+// multusAdmissionControllerStartupBudgetSeconds returns how long the startup probe lets the
+// controller start before the pod is restarted
+func multusAdmissionControllerStartupBudgetSeconds(conf *bootstrap.MultusAdmissionControllerBootstrapResult) int {
+	return valueOrDefault(conf.StartupProbePeriodSeconds, defaultMultusAdmissionControllerStartupProbePeriodSeconds) *
+		valueOrDefault(conf.StartupProbeFailureThreshold, defaultMultusAdmissionControllerStartupProbeFailureThreshold)
+}
+
+// valueOrDefault returns *value, or def if value is nil
+func valueOrDefault(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// validateMultusAdmissionControllerRollingUpdate checks a rolling update maxUnavailable or maxSurge
+// value, returning it scaled to a percentage, if it is one
+func validateMultusAdmissionControllerRollingUpdate(key string, value *intstr.IntOrString) (int, error) {
+	if value == nil {
+		return 0, nil
+	}
+	v, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value.String(), err)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must not be negative", key, value.String())
+	}
+	if key == "max-unavailable" && value.Type == intstr.String && v > 100 {
+		return 0, fmt.Errorf("invalid %s %q: must not be greater than 100%%", key, value.String())
+	}
+	return v, nil
+}
+
+// ManagementClusterUnavailableError is returned when the HyperShift management cluster can't be
+// reached, as opposed to a problem with the guest cluster configuration
+type ManagementClusterUnavailableError struct {
+	Err error
+}
+
+func (e *ManagementClusterUnavailableError) Error() string {
+	return fmt.Sprintf("management cluster is unavailable: %v", e.Err)
+}
+
+func (e *ManagementClusterUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// multusAdmissionControllerClusterName returns the name of the cluster the admission controller
+// runs in, and its lookups target: the HyperShift management cluster, or the default cluster
+func multusAdmissionControllerClusterName(hsc *bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult) string {
+	if hsc.Enabled {
+		return names.ManagementClusterName
+	}
+	return ""
+}
+
+// getMultusAdmissionControllerClusterClient returns the client of clusterName, or a
+// ManagementClusterUnavailableError if the operator has none
+func getMultusAdmissionControllerClusterClient(client cnoclient.Client, clusterName string) (cnoclient.ClusterClient, error) {
+	clusterClient := client.ClientFor(clusterName)
+	if clusterClient == nil {
+		return nil, &ManagementClusterUnavailableError{Err: fmt.Errorf("no client for cluster %s", clusterName)}
+	}
+	return clusterClient, nil
+}
+
+// newManagementClusterError wraps err, returned by a management cluster request, in a
+// ManagementClusterUnavailableError if the management cluster API server couldn't serve it
+func newManagementClusterError(err error) error {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || apierrors.IsServiceUnavailable(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) {
+		return &ManagementClusterUnavailableError{Err: err}
+	}
+	return err
+}
+
+// multusAdmissionControllerTracerName is the OpenTelemetry tracer the render phases are traced
+// with; the spans are no-ops unless a global tracer provider is installed
+const multusAdmissionControllerTracerName = "github.com/openshift/cluster-network-operator/pkg/network/multus-admission-controller"
+
+// traceMultusAdmissionControllerPhase runs phase in a span named name, child of the span in ctx,
+// and records the error phase returns on the span
+func traceMultusAdmissionControllerPhase(ctx context.Context, name string, phase func() error) error {
+	_, span := otel.Tracer(multusAdmissionControllerTracerName).Start(ctx, name)
+	defer span.End()
+	err := phase()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// multusAdmissionControllerTemplatePath returns the directory of the admission controller
+// templates under manifestDir
+func multusAdmissionControllerTemplatePath(manifestDir string) string {
+	return filepath.Join(manifestDir, "network/multus-admission-controller")
+}
+
+// renderMultusAdmissonControllerConfig returns the manifests of Multus Admisson Controller
+func renderMultusAdmissonControllerConfig(conf *operv1.NetworkSpec, manifestDir string, externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, featureGates FeatureGateSet) ([]*uns.Unstructured, error) {
+	return renderMultusAdmissionControllerTemplates(conf, multusAdmissionControllerTemplatePath(manifestDir), externalControlPlane, bootstrapResult, client, featureGates)
+}
+
+// RenderMultusAdmissionControllerWebhookConfiguration renders the multus admission controller and
+// returns only its ValidatingWebhookConfiguration, for targeted reconciliation and debugging. The
+// object goes through the full render, so it is the one the full render applies. It returns nil
+// when the render has no webhook configuration, with multi-network disabled or the Template output.
+func RenderMultusAdmissionControllerWebhookConfiguration(conf *operv1.NetworkSpec, manifestDir string, externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, featureGates featuregates.FeatureGate) (*uns.Unstructured, error) {
+	objs, err := renderMultusAdmissionController(conf, manifestDir, externalControlPlane, bootstrapResult, client, featureGates)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		if obj.GroupVersionKind() == admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration") &&
+			obj.GetName() == names.MULTUS_VALIDATING_WEBHOOK {
+			return obj, nil
+		}
+	}
+	return nil, nil
+}
+
+// renderMultusAdmissionControllerTemplates returns the manifests of the admission controller
+// rendered from the templates in templatePath
+func renderMultusAdmissionControllerTemplates(conf *operv1.NetworkSpec, templatePath string, externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, featureGates FeatureGateSet) ([]*uns.Unstructured, error) {
+	ctx, span := otel.Tracer(multusAdmissionControllerTracerName).Start(context.Background(), "RenderMultusAdmissionController")
+	defer span.End()
+	objs, err := renderMultusAdmissionControllerPhases(ctx, conf, templatePath, externalControlPlane, bootstrapResult, client, featureGates)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return objs, err
+}
+
+// renderMultusAdmissionControllerPhases renders the admission controller, tracing each phase in
+// a child span of the span in ctx
+func renderMultusAdmissionControllerPhases(ctx context.Context, conf *operv1.NetworkSpec, templatePath string, externalControlPlane bool, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, featureGates FeatureGateSet) ([]*uns.Unstructured, error) {
+	objs := []*uns.Unstructured{}
+	var err error
+
+	if err := validateMultusAdmissionControllerBootstrap(bootstrapResult); err != nil {
+		return nil, err
+	}
+	if err := validateMultusAdmissionControllerTopology(externalControlPlane, bootstrapResult); err != nil {
+		return nil, err
+	}
+	acConf := &bootstrapResult.MultusAdmissionController
+	if err := validateMultusAdmissionControllerConfig(acConf); err != nil {
+		return nil, err
+	}
+
+	replicas := getMultusAdmissionControllerReplicas(bootstrapResult)
+	var refreshInterval time.Duration
+	if acConf.IgnoredNamespacesRefreshInterval != nil {
+		refreshInterval = *acConf.IgnoredNamespacesRefreshInterval
+	}
+	gracePeriod := defaultMultusAdmissionControllerIgnoredNamespacesGracePeriod
+	if acConf.IgnoredNamespacesGracePeriod != nil {
+		gracePeriod = *acConf.IgnoredNamespacesGracePeriod
+	}
+	traceMultusAdmissionControllerPhase(ctx, "DiscoverIgnoredNamespaces", func() error {
+		updateIgnoredNamespaces(client, refreshInterval, gracePeriod, time.Now())
+		return nil
+	})
+
+	namespaces := getIgnoredNamespaces()
+
+	// render the manifests on disk
+	data := render.MakeRenderData()
+	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
+	// templates check gates with {{ if index .FeatureGates "Name" }}
+	if featureGates == nil {
+		featureGates = FeatureGateSet{}
+	}
+	data.Data["FeatureGates"] = featureGates
+	multusAdmissionControllerImage, err := getMultusAdmissionControllerImage("MULTUS_ADMISSION_CONTROLLER_IMAGE")
+	if err != nil {
+		return nil, err
+	}
+	data.Data["MultusAdmissionControllerImage"] = resolveImageDigest(multusAdmissionControllerImage, acConf.ImageDigests)
+	data.Data["IgnoredNamespace"] = strings.Join(namespaces, ",")
+	data.Data["MultusValidatingWebhookName"] = names.MULTUS_VALIDATING_WEBHOOK
+	if data.Data["KubeRBACProxyImage"], err = getMultusAdmissionControllerKubeRBACProxyImage(acConf.KubeRBACProxyFallbackImage); err != nil {
+		return nil, err
+	}
+	data.Data["ExternalControlPlane"] = externalControlPlane
+	data.Data["FailurePolicy"] = string(EffectiveMultusAdmissionControllerFailurePolicy(acConf))
+	if acConf.Paused {
+		klog.Infof("multus admission controller is paused, scaling it to zero replicas")
+		replicas = 0
+	}
+	data.Data["Replicas"] = replicas
+	data.Data["SessionAffinity"] = corev1.ServiceAffinityNone
+	if acConf.SessionAffinity != "" {
+		data.Data["SessionAffinity"] = acConf.SessionAffinity
+	}
+	data.Data["InternalTrafficPolicy"] = corev1.ServiceInternalTrafficPolicyCluster
+	if acConf.InternalTrafficPolicy != "" {
+		data.Data["InternalTrafficPolicy"] = acConf.InternalTrafficPolicy
+	}
+	if acConf.InternalTrafficPolicy == corev1.ServiceInternalTrafficPolicyLocal && replicas <= 1 {
+		klog.Warningf("multus admission controller Service uses internal traffic policy %q with %d replicas, "+
+			"most nodes will have no local webhook endpoint", corev1.ServiceInternalTrafficPolicyLocal, replicas)
+	}
+	data.Data["SessionAffinityTimeout"] = 0
+	if acConf.SessionAffinityTimeout != nil {
+		data.Data["SessionAffinityTimeout"] = *acConf.SessionAffinityTimeout
+	}
+	data.Data["DNSPolicy"] = corev1.DNSClusterFirst
+	if acConf.DNSPolicy != "" {
+		data.Data["DNSPolicy"] = acConf.DNSPolicy
+	}
+	data.Data["DNSNameservers"] = acConf.DNSNameservers
+	data.Data["DNSSearches"] = acConf.DNSSearches
+	data.Data["HealthProbes"] = acConf.HealthProbes
+	data.Data["ProbeInitialDelaySeconds"] = valueOrDefault(acConf.ProbeInitialDelaySeconds, defaultMultusAdmissionControllerProbeInitialDelaySeconds)
+	data.Data["ProbePeriodSeconds"] = valueOrDefault(acConf.ProbePeriodSeconds, defaultMultusAdmissionControllerProbePeriodSeconds)
+	data.Data["ProbeTimeoutSeconds"] = valueOrDefault(acConf.ProbeTimeoutSeconds, defaultMultusAdmissionControllerProbeTimeoutSeconds)
+	data.Data["ProbeFailureThreshold"] = valueOrDefault(acConf.ProbeFailureThreshold, defaultMultusAdmissionControllerProbeFailureThreshold)
+	data.Data["LivenessFailureThreshold"] = valueOrDefault(acConf.ProbeFailureThreshold, defaultMultusAdmissionControllerProbeFailureThreshold) *
+		multusAdmissionControllerLivenessFailureMultiplier
+	data.Data["StartupProbePeriodSeconds"] = valueOrDefault(acConf.StartupProbePeriodSeconds, defaultMultusAdmissionControllerStartupProbePeriodSeconds)
+	ephemeralStorageRequest, ephemeralStorageLimit := multusAdmissionControllerEphemeralStorage(acConf)
+	data.Data["EphemeralStorageRequest"] = ephemeralStorageRequest.String()
+	data.Data["EphemeralStorageLimit"] = ephemeralStorageLimit.String()
+	data.Data["ScratchVolumeSizeLimit"] = defaultMultusAdmissionControllerScratchVolumeSizeLimit.String()
+	if acConf.ScratchVolumeSizeLimit != nil {
+		data.Data["ScratchVolumeSizeLimit"] = acConf.ScratchVolumeSizeLimit.String()
+	}
+	data.Data["PreStopSleepSeconds"] = valueOrDefault(acConf.PreStopSleepSeconds, defaultMultusAdmissionControllerPreStopSleepSeconds)
+	data.Data["StartupProbeFailureThreshold"] = valueOrDefault(acConf.StartupProbeFailureThreshold, defaultMultusAdmissionControllerStartupProbeFailureThreshold)
+	data.Data["ReadinessPath"] = multusAdmissionControllerReadinessPath
+	data.Data["LivenessPath"] = multusAdmissionControllerLivenessPath
+	data.Data["SeccompProfileType"] = corev1.SeccompProfileTypeRuntimeDefault
+	if acConf.SeccompProfileType != "" {
+		data.Data["SeccompProfileType"] = acConf.SeccompProfileType
+	}
+	data.Data["SeccompLocalhostProfile"] = acConf.SeccompLocalhostProfile
+	data.Data["CommandOverride"] = acConf.CommandOverride
+	if len(acConf.CommandOverride) > 0 {
+		klog.Warningf("multus admission controller command is overridden with %q, for development only", acConf.CommandOverride)
+	}
+	var admissionReviewVersions, nadVersions []string
+	matchPolicy := admissionregistrationv1.Equivalent
+	if acConf.WebhookMatchPolicy != "" {
+		matchPolicy = acConf.WebhookMatchPolicy
+	}
+	var autoscaling bool
+	if err := traceMultusAdmissionControllerPhase(ctx, "DiscoverAPIServer", func() (err error) {
+		if len(acConf.ExtraWebhookRules) > 0 {
+			if err := validateMultusAdmissionControllerWebhookRuleResources(acConf.ExtraWebhookRules, client); err != nil {
+				return err
+			}
+		}
+		admissionReviewVersions, err = getMultusAdmissionControllerAdmissionReviewVersions(client, acConf.AdmissionReviewVersions)
+		if err != nil {
+			return err
+		}
+		nadVersions = getMultusAdmissionControllerNADVersions(client, matchPolicy)
+		if overlapping, err := getMultusAdmissionControllerOverlappingWebhooks(client); err != nil {
+			klog.Warningf("Failed to check for webhooks overlapping the multus admission controller: %v", err)
+		} else {
+			reportMultusAdmissionControllerOverlappingWebhooks(overlapping)
+		}
+		autoscaling, err = multusAdmissionControllerAutoscaling(acConf, client)
+		if err == nil && acConf.Autoscaling && !autoscaling {
+			klog.Warningf("autoscaling is ignored, %s horizontalpodautoscalers are not served by the cluster",
+				autoscalingv2.SchemeGroupVersion)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	data.Data["ExtraWebhookRules"] = acConf.ExtraWebhookRules
+	data.Data["WebhookObjectSelector"] = (*metav1.LabelSelector)(nil)
+	if acConf.WebhookObjectSelector != "" {
+		objectSelector, err := metav1.ParseToLabelSelector(acConf.WebhookObjectSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook-object-selector %q: %w", acConf.WebhookObjectSelector, err)
+		}
+		data.Data["WebhookObjectSelector"] = objectSelector
+	}
+	data.Data["WebhookNamespaceSelector"] = multusAdmissionControllerWebhookNamespaceSelector(acConf.NamespaceSelectorStrategy, namespaces)
+	data.Data["AdmissionReviewVersions"] = admissionReviewVersions
+	data.Data["NADAPIVersions"] = nadVersions
+	data.Data["WebhookMatchPolicy"] = string(matchPolicy)
+	// the installed plugins, for the controller to reject NADs of unknown types
+	data.Data["CNIPlugins"] = strings.Join(getInstalledCNIPlugins(conf, acConf.AdditionalCNIPlugins), ",")
+	data.Data["ReconcileInterval"] = ""
+	if acConf.ReconcileInterval != nil {
+		data.Data["ReconcileInterval"] = acConf.ReconcileInterval.String()
+	}
+	// unset, the SCC assigns the pod groups
+	data.Data["FSGroupSet"] = acConf.FSGroup != nil
+	data.Data["FSGroup"] = valueOrDefault(acConf.FSGroup, 0)
+	data.Data["SupplementalGroups"] = acConf.SupplementalGroups
+	data.Data["RevisionHistoryLimit"] = valueOrDefault(acConf.RevisionHistoryLimit, defaultMultusAdmissionControllerRevisionHistoryLimit)
+	data.Data["ProgressDeadlineSeconds"] = valueOrDefault(acConf.ProgressDeadlineSeconds, defaultMultusAdmissionControllerProgressDeadlineSeconds)
+	data.Data["WebhookPort"] = multusAdmissionControllerWebhookPort
+	data.Data["MetricsListenPort"] = multusAdmissionControllerMetricsListenPort
+	data.Data["MetricsPort"] = defaultMultusAdmissionControllerMetricsPort
+	if acConf.MetricsPort != nil {
+		data.Data["MetricsPort"] = *acConf.MetricsPort
+	}
+	data.Data["MetricsPath"] = defaultMultusAdmissionControllerMetricsPath
+	if acConf.MetricsPath != "" {
+		data.Data["MetricsPath"] = path.Clean(acConf.MetricsPath)
+	}
+	data.Data["SideEffects"] = admissionregistrationv1.SideEffectClassNoneOnDryRun
+	if acConf.SideEffects != "" {
+		data.Data["SideEffects"] = acConf.SideEffects
+	}
+	data.Data["ServiceAccountName"] = defaultMultusAdmissionControllerServiceAccountName
+	if acConf.ServiceAccountName != "" {
+		data.Data["ServiceAccountName"] = acConf.ServiceAccountName
+	}
+	data.Data["RollingUpdateConfigured"] = acConf.MaxUnavailable != nil || acConf.MaxSurge != nil
+	data.Data["MaxUnavailable"] = defaultMultusAdmissionControllerRollingUpdate.String()
+	if acConf.MaxUnavailable != nil {
+		data.Data["MaxUnavailable"] = acConf.MaxUnavailable.String()
+	}
+	data.Data["MaxSurge"] = defaultMultusAdmissionControllerRollingUpdate.String()
+	if acConf.MaxSurge != nil {
+		data.Data["MaxSurge"] = acConf.MaxSurge.String()
+	}
+	data.Data["WebhookWorkerCount"] = ""
+	if acConf.WebhookWorkerCount != nil {
+		data.Data["WebhookWorkerCount"] = strconv.Itoa(*acConf.WebhookWorkerCount)
+	}
+	data.Data["LogLevel"] = ""
+	if acConf.LogLevel != nil {
+		data.Data["LogLevel"] = strconv.Itoa(*acConf.LogLevel)
+	}
+	// Cluster IPAM context, for NAD validation hints
+	_, useWhereabouts := detectAuxiliaryIPAM(conf)
+	data.Data["IPAMHints"] = acConf.IPAMHints
+	data.Data["ServiceNetwork"] = strings.Join(conf.ServiceNetwork, ",")
+	clusterNetwork := []string{}
+	for _, entry := range conf.ClusterNetwork {
+		clusterNetwork = append(clusterNetwork, entry.CIDR)
+	}
+	data.Data["ClusterNetwork"] = strings.Join(clusterNetwork, ",")
+	data.Data["WhereaboutsEnabled"] = useWhereabouts
+	// Hypershift
+	hsc := multusAdmissionControllerHyperShiftConfig(acConf)
+	clusterName := multusAdmissionControllerClusterName(hsc)
+	data.Data["HyperShiftEnabled"] = hsc.Enabled
+	// In HyperShift metrics are always encrypted by the admission controller itself
+	// the hosted cluster token is minted explicitly in HyperShift, so the management
+	// cluster token isn't mounted by default
+	// a mesh sidecar intercepts the webhook TLS, so injection is disabled unless asked for
+	data.Data["DisableMeshInjection"] = acConf.MeshInjection == nil || !*acConf.MeshInjection
+	data.Data["AutomountServiceAccountToken"] = ""
+	if hsc.Enabled {
+		data.Data["AutomountServiceAccountToken"] = "false"
+	}
+	data.Data["ProjectServiceAccountToken"] = false
+	if acConf.AutomountServiceAccountToken != nil {
+		data.Data["AutomountServiceAccountToken"] = strconv.FormatBool(*acConf.AutomountServiceAccountToken)
+		// outside of HyperShift the controller authenticates with its service account token
+		data.Data["ProjectServiceAccountToken"] = !hsc.Enabled && !*acConf.AutomountServiceAccountToken
+	}
+	// in HyperShift the admission controller runs in the management cluster, away from multus
+	data.Data["RoleAggregation"] = acConf.RoleAggregation
+	data.Data["RoleAggregationLabel"] = multusAdmissionControllerRoleAggregationLabel
+	data.Data["Autoscaling"] = autoscaling
+	if autoscaling {
+		minReplicas := valueOrDefault(acConf.AutoscalingMinReplicas, replicas)
+		maxReplicas := valueOrDefault(acConf.AutoscalingMaxReplicas, defaultMultusAdmissionControllerAutoscalingMaxReplicas)
+		if maxReplicas < minReplicas {
+			return nil, fmt.Errorf("invalid autoscaling-max-replicas %d: must not be less than the minimum replicas %d", maxReplicas, minReplicas)
+		}
+		data.Data["AutoscalingMinReplicas"] = minReplicas
+		data.Data["AutoscalingMaxReplicas"] = maxReplicas
+		// the CPU target is the default unless a memory target replaces it
+		cpuUtilization := 0
+		if acConf.AutoscalingCPUUtilization != nil {
+			cpuUtilization = *acConf.AutoscalingCPUUtilization
+		} else if acConf.AutoscalingMemoryUtilization == nil {
+			cpuUtilization = defaultMultusAdmissionControllerAutoscalingCPUUtilization
+		}
+		data.Data["AutoscalingCPUUtilization"] = cpuUtilization
+		data.Data["AutoscalingMemoryUtilization"] = valueOrDefault(acConf.AutoscalingMemoryUtilization, 0)
+	}
+	data.Data["SplitRBAC"] = acConf.SplitRBAC
+	data.Data["ExtraEnv"] = acConf.ExtraEnv
+	data.Data["RejectionEvents"] = acConf.RejectionEvents
+	data.Data["ShutdownTimeout"] = ""
+	if acConf.ShutdownTimeout != nil {
+		data.Data["ShutdownTimeout"] = acConf.ShutdownTimeout.String()
+	}
+	data.Data["AuditLogDir"] = ""
+	if acConf.AuditLogDir != "" {
+		data.Data["AuditLogDir"] = path.Clean(acConf.AuditLogDir)
+	}
+	data.Data["AuditLogHostPath"] = acConf.AuditLogHostPath
+	data.Data["MultusAffinity"] = acConf.MultusAffinity && !hsc.Enabled
+	if acConf.MultusAffinity && hsc.Enabled {
+		klog.Infof("multus-affinity is ignored in HyperShift")
+	}
+	data.Data["HostAliases"] = []corev1.HostAlias{}
+	if hsc.Enabled {
+		data.Data["HostAliases"] = acConf.HostAliases
+	} else if len(acConf.HostAliases) > 0 {
+		klog.Infof("host-aliases is ignored without HyperShift")
+	}
+	data.Data["KubeRBACProxyEnabled"] = !hsc.Enabled && acConf.MetricsAuth != multusAdmissionControllerMetricsAuthNone
+	data.Data["ManagementClusterName"] = names.ManagementClusterName
+	data.Data["ServingCertSecret"] = defaultMultusAdmissionControllerServingCertSecret
+	data.Data["ExternalServingCert"] = false
+	data.Data["WebhookCABundle"] = ""
+	data.Data["ServingCertHash"] = ""
+	data.Data["ServiceCAKey"] = defaultMultusAdmissionControllerServiceCAKey
+	if acConf.ServiceCAKey != "" {
+		data.Data["ServiceCAKey"] = acConf.ServiceCAKey
+	}
+	data.Data["ImagePullPolicy"] = string(corev1.PullIfNotPresent)
+	if acConf.ImagePullPolicy != "" {
+		data.Data["ImagePullPolicy"] = string(acConf.ImagePullPolicy)
+	}
+	data.Data["CertReloadStrategy"] = multusAdmissionControllerCertReloadPodRestart
+	if acConf.CertReloadStrategy != "" {
+		data.Data["CertReloadStrategy"] = acConf.CertReloadStrategy
+	}
+	data.Data["AdmissionControllerNamespace"] = multusAdmissionControllerNamespaceName(hsc)
+	data.Data["RunAsUser"] = strconv.Itoa(defaultMultusAdmissionControllerRunAsUser)
+	data.Data["RHOBSMonitoring"] = os.Getenv("RHOBS_MONITORING")
+	data.Data["DisableServiceMonitor"] = acConf.DisableServiceMonitor
+	data.Data["MetricsService"] = acConf.MetricsService
+	renderNamespace := multusAdmissionControllerRendersNamespace(acConf, hsc)
+	data.Data["RenderNamespace"] = renderNamespace
+	if hsc.Enabled && !renderNamespace {
+		if err := traceMultusAdmissionControllerPhase(ctx, "GetManagementNamespace", func() error {
+			mgmtClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+			if err != nil {
+				return err
+			}
+			namespace := &corev1.Namespace{}
+			err = mgmtClient.CRClient().Get(context.TODO(), types.NamespacedName{Name: hsc.Namespace}, namespace)
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("multus admission controller namespace %s does not exist in the management cluster; "+
+					"create it, or set namespace-policy to %q in the %s/%s configmap", hsc.Namespace,
+					multusAdmissionControllerNamespaceRender, names.APPLIED_NAMESPACE, names.MULTUS_ADMISSION_CONTROLLER_CONFIG)
+			}
+			if err != nil {
+				return newManagementClusterError(fmt.Errorf("failed to get multus admission controller namespace %s: %w", hsc.Namespace, err))
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if hsc.Enabled {
+		apiServer := bootstrapResult.Infra.APIServers[multusAdmissionControllerTokenMinterAPIServer(acConf)]
+		data.Data["KubernetesServiceHost"] = apiServer.Host
+		data.Data["KubernetesServicePort"] = apiServer.Port
+		if data.Data["CLIImage"], err = getMultusAdmissionControllerImage("CLI_IMAGE"); err != nil {
+			return nil, err
+		}
+		if data.Data["TokenMinterImage"], err = getMultusAdmissionControllerImage("TOKEN_MINTER_IMAGE"); err != nil {
+			return nil, err
+		}
+		data.Data["TokenAudience"] = os.Getenv("TOKEN_AUDIENCE")
+		data.Data["TokenMinterRequestTimeout"] = defaultMultusAdmissionControllerTokenMinterRequestTimeout.String()
+		if acConf.TokenMinterRequestTimeout != nil {
+			data.Data["TokenMinterRequestTimeout"] = acConf.TokenMinterRequestTimeout.String()
+		}
+		data.Data["RunAsUser"] = hsc.RunAsUser
+		data.Data["TokenMountPath"] = defaultMultusAdmissionControllerTokenMountPath
+		if acConf.TokenMountPath != "" {
+			data.Data["TokenMountPath"] = path.Clean(acConf.TokenMountPath)
+		}
+
+		if acConf.ServingCertSecret == "" {
+			// Get serving CA from the management cluster since the service resides there
+			if err := traceMultusAdmissionControllerPhase(ctx, "GetServiceCA", func() error {
+				mgmtClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+				if err != nil {
+					return err
+				}
+				serviceCAKey := data.Data["ServiceCAKey"].(string)
+				serviceCA, err := getManagementServiceCA(mgmtClient, hsc.Namespace, serviceCAKey)
+				if err != nil {
+					return err
+				}
+				ca, exists := serviceCA.Data[serviceCAKey]
+				if !exists {
+					keys := sets.New[string]()
+					for key := range serviceCA.Data {
+						keys.Insert(key)
+					}
+					for key := range serviceCA.BinaryData {
+						keys.Insert(key)
+					}
+					return fmt.Errorf("(%s) %s/%s missing %q key, selected by service-ca-key; available keys: %v",
+						serviceCA.GroupVersionKind(), serviceCA.Namespace, serviceCA.Name, serviceCAKey, sets.List(keys))
+				}
+
+				data.Data["WebhookCABundle"] = base64.URLEncoding.EncodeToString([]byte(ca))
+				// The CA hash is rendered in the pod template so that a CA rotation rolls out the pods
+				caHash := sha1.Sum([]byte(ca))
+				data.Data["ServingCertHash"] = hex.EncodeToString(caHash[:])
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		data.Data["ClusterIDLabel"] = platform.ClusterIDLabel
+		data.Data["ClusterID"] = bootstrapResult.Infra.HostedControlPlane.Spec.ClusterID
+		if data.Data["ClusterID"] == "" {
+			// a HostedControlPlane that is still being initialized may not have its cluster ID yet;
+			// the metrics are better left unlabelled than labelled with an empty cluster
+			if acConf.RequireClusterID {
+				return nil, fmt.Errorf("HostedControlPlane %s/%s has no cluster ID, required by require-cluster-id",
+					hsc.Namespace, bootstrapResult.Infra.HostedControlPlane.Name)
+			}
+			klog.Warningf("HostedControlPlane %s/%s has no cluster ID, the multus admission controller metrics will not be labelled with %s",
+				hsc.Namespace, bootstrapResult.Infra.HostedControlPlane.Name, platform.ClusterIDLabel)
+		}
+		data.Data["HCPNodeSelector"] = bootstrapResult.Infra.HostedControlPlane.Spec.NodeSelector
+
+		data.Data["ReleaseImage"] = hsc.ReleaseImage
+	}
+
+	if acConf.ServingCertSecret != "" {
+		var secret *corev1.Secret
+		if err := traceMultusAdmissionControllerPhase(ctx, "GetServingCertSecret", func() (err error) {
+			secret, err = getMultusAdmissionControllerServingCertSecret(client, clusterName, data.Data["AdmissionControllerNamespace"].(string),
+				acConf.ServingCertSecret)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		data.Data["ServingCertSecret"] = acConf.ServingCertSecret
+		data.Data["ExternalServingCert"] = true
+		// without a ca.crt, the certificate must be trusted by the API server system roots
+		if ca := secret.Data["ca.crt"]; len(ca) > 0 {
+			data.Data["WebhookCABundle"] = base64.URLEncoding.EncodeToString(ca)
+		}
+		certHash := sha1.Sum(secret.Data["tls.crt"])
+		data.Data["ServingCertHash"] = hex.EncodeToString(certHash[:])
+	}
+
+	if !hsc.Enabled && acConf.ServingCertSecret == "" {
+		// the service CA operator injects the caBundle, and its CA in the namespace ConfigMap
+		if err := traceMultusAdmissionControllerPhase(ctx, "GetServiceCA", func() (err error) {
+			data.Data["ServingCertHash"], err = getMultusAdmissionControllerServiceCAHash(client,
+				data.Data["AdmissionControllerNamespace"].(string), data.Data["ServiceCAKey"].(string))
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if acConf.RunAsUserSource == multusAdmissionControllerRunAsUserNamespaceRange {
+		uid, err := getMultusAdmissionControllerNamespaceUID(client, clusterName, data.Data["AdmissionControllerNamespace"].(string))
+		if err != nil {
+			return nil, err
+		}
+		data.Data["RunAsUser"] = strconv.FormatInt(uid, 10)
+	}
+
+	data.Data["RuntimeClassName"] = acConf.RuntimeClassName
+	if acConf.RuntimeClassName != "" {
+		if err := checkMultusAdmissionControllerRuntimeClass(client, clusterName, acConf.RuntimeClassName); err != nil {
+			return nil, err
+		}
+	}
+
+	data.Data["TopologyMode"] = ""
+	if acConf.TopologyMode != "" {
+		// the pods are scheduled on the control plane nodes, or the HyperShift node selector ones
+		nodeSelector := map[string]string{"node-role.kubernetes.io/master": ""}
+		if hsc.Enabled {
+			nodeSelector = bootstrapResult.Infra.HostedControlPlane.Spec.NodeSelector
+		}
+		zones, err := getMultusAdmissionControllerNodeZones(client, clusterName, nodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		if replicas > 1 && len(zones) > 1 {
+			data.Data["TopologyMode"] = acConf.TopologyMode
+		} else {
+			klog.Warningf("topology-mode is ignored, the %d multus admission controller replicas can't span the zones %v",
+				replicas, zones)
+		}
+	}
+
+	deferred, err := multusAdmissionControllerWebhookRegistrationDeferred(acConf, client, clusterName, data.Data["AdmissionControllerNamespace"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if deferred {
+		klog.Infof("multus admission controller webhook will not be created, the controller endpoints are not ready")
+	}
+	data.Data["WebhookRegistrationDeferred"] = deferred
+	data.Data["OutputFormat"] = multusAdmissionControllerOutputFormat(acConf)
+
+	var manifests []*uns.Unstructured
+	if err := traceMultusAdmissionControllerPhase(ctx, "RenderDir", func() (err error) {
+		manifests, err = render.RenderDir(templatePath, &data)
+		return errors.Wrap(err, "failed to render multus admission controller manifests")
+	}); err != nil {
+		return nil, err
+	}
+	if acConf.WebhookRegistration == multusAdmissionControllerWebhookRegistrationAfterReady {
+		manifests = orderMultusAdmissionControllerWebhookRegistration(manifests, data.Data["WebhookRegistrationDeferred"].(bool))
+	}
+	if acConf.Revision != "" {
+		manifests, err = renderMultusAdmissionControllerRevisions(manifests, acConf, client, clusterName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, mutate := range multusAdmissionControllerRenderMutators {
+		if manifests, err = mutate(manifests); err != nil {
+			return nil, errors.Wrapf(err, "multus admission controller render mutator %d failed", i)
+		}
+	}
+	setMultusAdmissionControllerBackupAnnotations(manifests, acConf.BackupAnnotations)
+	if acConf.WebhookUpdateStrategy == multusAdmissionControllerWebhookUpdateReplaceOnRulesChange {
+		if err := setMultusAdmissionControllerWebhookRulesHash(manifests); err != nil {
+			return nil, err
+		}
+	}
+	if acConf.IgnoredNamespacesHash {
+		ignored, _ := data.Data["IgnoredNamespace"].(string)
+		setMultusAdmissionControllerIgnoredNamespacesHash(manifests, ignored)
+	}
+	if err := traceMultusAdmissionControllerPhase(ctx, "Validate", func() error {
+		if err := validateMultusAdmissionControllerUniqueObjects(manifests); err != nil {
+			return err
+		}
+		if err := validateMultusAdmissionControllerWebhookServices(manifests); err != nil {
+			return err
+		}
+		if acConf.SchemaValidation {
+			return validateMultusAdmissionControllerSchemas(manifests, client)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if acConf.OutputFormat == multusAdmissionControllerOutputFormatTemplate {
+		if hsc.Enabled {
+			return nil, fmt.Errorf("invalid output-format %q: not supported with HyperShift", acConf.OutputFormat)
+		}
+		template, err := renderMultusAdmissionControllerTemplate(manifests, data.Data["AdmissionControllerNamespace"].(string),
+			data.Data["MultusAdmissionControllerImage"].(string), data.Data["Replicas"].(int))
+		if err != nil {
+			return nil, err
+		}
+		manifests = []*uns.Unstructured{template}
+	}
+	if acConf.ReconcileInterval != nil {
+		setMultusAdmissionControllerReconcileInterval(manifests, data.Data["ReconcileInterval"].(string))
+	}
+	if err := traceMultusAdmissionControllerPhase(ctx, "ValidateObjectSizes", func() error {
+		return validateMultusAdmissionControllerObjectSizes(manifests)
+	}); err != nil {
+		return nil, err
+	}
+	objs = append(objs, manifests...)
+	summary := multusAdmissionControllerRenderSummary(&data, len(manifests))
+	if fingerprint, err := MultusAdmissionControllerCABundleFingerprint(manifests); err != nil {
+		klog.Warningf("Failed to fingerprint the multus admission controller caBundle: %v", err)
+	} else if fingerprint != "" {
+		summary = append(summary, "caBundleFingerprint", fingerprint)
+	}
+	klog.InfoS("Rendered multus admission controller", summary...)
+	multusAdmissionControllerIgnoredNamespaces.Set(float64(len(namespaces)))
+	return objs, nil
+}
+
+// setMultusAdmissionControllerReconcileInterval annotates the rendered objects with the
+// reconcile-interval hint, so that the operconfig controller backs off its periodic resync, of all
+// the network components, to that interval.
+func setMultusAdmissionControllerReconcileInterval(objs []*uns.Unstructured, interval string) {
+	for _, obj := range objs {
+		anno := obj.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[names.ReconcileIntervalAnnotation] = interval
+		obj.SetAnnotations(anno)
+	}
+}
+
+// renderMultusAdmissionControllerTemplate wraps the rendered objects in an OpenShift Template, in
+// the operator namespace, with the namespace, the image and the replicas as parameters
+func renderMultusAdmissionControllerTemplate(objs []*uns.Unstructured, namespace, image string, replicas int) (*uns.Unstructured, error) {
+	parameterize := func(value string) string {
+		if value == namespace {
+			return "${NAMESPACE}"
+		}
+		if image != "" && value == image {
+			return "${IMAGE}"
+		}
+		return strings.ReplaceAll(value, "."+namespace+".svc", ".${NAMESPACE}.svc")
+	}
+
+	template := &templatev1.Template{
+		TypeMeta: metav1.TypeMeta{APIVersion: templatev1.GroupVersion.String(), Kind: "Template"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "multus-admission-controller",
+			Namespace: names.APPLIED_NAMESPACE,
+		},
+		Parameters: []templatev1.Parameter{
+			{Name: "NAMESPACE", Description: "The namespace of the admission controller", Value: namespace, Required: true},
+			{Name: "IMAGE", Description: "The admission controller image", Value: image, Required: true},
+			{Name: "REPLICAS", Description: "The number of admission controller replicas", Value: strconv.Itoa(replicas), Required: true},
+		},
+	}
+	for _, obj := range objs {
+		obj = &uns.Unstructured{Object: parameterizeMultusAdmissionControllerValue(obj.DeepCopy().Object, parameterize).(map[string]interface{})}
+		// autoscaled Deployments have no replicas to parameterize
+		if _, hasReplicas, _ := uns.NestedFieldNoCopy(obj.Object, "spec", "replicas"); obj.GroupVersionKind() == appsv1.SchemeGroupVersion.WithKind("Deployment") && hasReplicas {
+			// ${{...}} parameters are substituted as JSON values rather than strings
+			if err := uns.SetNestedField(obj.Object, "${{REPLICAS}}", "spec", "replicas"); err != nil {
+				return nil, errors.Wrapf(err, "failed to parameterize the replicas of Deployment %s", obj.GetName())
+			}
+		}
+		raw, err := obj.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		template.Objects = append(template.Objects, runtime.RawExtension{Raw: raw})
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the multus admission controller Template")
+	}
+	return &uns.Unstructured{Object: content}, nil
+}
+
+// parameterizeMultusAdmissionControllerValue applies parameterize to every string in value
+func parameterizeMultusAdmissionControllerValue(value interface{}, parameterize func(string) string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = parameterizeMultusAdmissionControllerValue(item, parameterize)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = parameterizeMultusAdmissionControllerValue(item, parameterize)
+		}
+	case string:
+		return parameterize(v)
+	}
+	return value
+}
+
+// validateMultusAdmissionControllerWebhookServices checks that every webhook served through a
+// Service references a Service of the rendered objects, and one of its ports
+func validateMultusAdmissionControllerWebhookServices(objs []*uns.Unstructured) error {
+	services := map[types.NamespacedName]*corev1.Service{}
+	for _, obj := range objs {
+		if obj.GroupVersionKind() != corev1.SchemeGroupVersion.WithKind("Service") {
+			continue
+		}
+		service := &corev1.Service{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, service); err != nil {
+			return errors.Wrapf(err, "failed to convert Service %s/%s", obj.GetNamespace(), obj.GetName())
+		}
+		services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = service
+	}
+	for _, obj := range objs {
+		if obj.GroupVersionKind() != admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration") {
+			continue
+		}
+		config := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, config); err != nil {
+			return errors.Wrapf(err, "failed to convert ValidatingWebhookConfiguration %s", obj.GetName())
+		}
+		for _, webhook := range config.Webhooks {
+			ref := webhook.ClientConfig.Service
+			if ref == nil {
+				continue
+			}
+			// the API server defaults the port to 443
+			port := int32(443)
+			if ref.Port != nil {
+				port = *ref.Port
+			}
+			service, ok := services[types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}]
+			if !ok {
+				return fmt.Errorf("webhook %s of ValidatingWebhookConfiguration %s references Service %s/%s, which is not rendered",
+					webhook.Name, config.Name, ref.Namespace, ref.Name)
+			}
+			found := false
+			for _, servicePort := range service.Spec.Ports {
+				if servicePort.Port == port {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("webhook %s of ValidatingWebhookConfiguration %s references port %d of Service %s/%s, which has no such port",
+					webhook.Name, config.Name, port, ref.Namespace, ref.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerSchemas validates the rendered objects against the OpenAPI
+// schema of the cluster each object is applied to, fetched through discovery
+func validateMultusAdmissionControllerSchemas(objs []*uns.Unstructured, client cnoclient.Client) error {
+	parsers := map[string]*managedfields.GvkParser{}
+	for _, obj := range objs {
+		clusterName := obj.GetAnnotations()[names.ClusterNameAnnotation]
+		parser, ok := parsers[clusterName]
+		if !ok {
+			clusterClient := client.ClientFor(clusterName)
+			if clusterClient == nil {
+				return fmt.Errorf("cannot validate multus admission controller objects: no client for cluster %q", clusterName)
+			}
+			doc, err := clusterClient.Kubernetes().Discovery().OpenAPISchema()
+			if err != nil {
+				return fmt.Errorf("failed to get the OpenAPI schema of cluster %q: %w", clusterName, err)
+			}
+			models, err := proto.NewOpenAPIData(doc)
+			if err != nil {
+				return fmt.Errorf("failed to parse the OpenAPI schema of cluster %q: %w", clusterName, err)
+			}
+			if parser, err = managedfields.NewGVKParser(models, false); err != nil {
+				return fmt.Errorf("failed to parse the OpenAPI schema of cluster %q: %w", clusterName, err)
+			}
+			parsers[clusterName] = parser
+		}
+		if err := validateMultusAdmissionControllerSchema(obj, parser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerSchema validates obj against the schema of its kind. Kinds
+// unknown to the schema, like the kinds of CRDs that aren't installed, are not validated.
+func validateMultusAdmissionControllerSchema(obj *uns.Unstructured, parser *managedfields.GvkParser) error {
+	parseableType := parser.Type(obj.GroupVersionKind())
+	if parseableType == nil {
+		klog.V(4).Infof("no OpenAPI schema for %s, skipping validation of %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		return nil
+	}
+	if _, err := parseableType.FromUnstructured(obj.Object); err != nil {
+		return fmt.Errorf("rendered %s %s/%s is invalid: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// RenderMutator modifies the rendered objects, returning the objects to apply. It can change
+// objects, like appending labels or swapping registries, or add new ones.
+type RenderMutator func(objs []*uns.Unstructured) ([]*uns.Unstructured, error)
+
+// multusAdmissionControllerRenderMutators are run in order after the multus admission controller
+// render. The core operator registers none.
+var multusAdmissionControllerRenderMutators []RenderMutator
+
+// RegisterMultusAdmissionControllerRenderMutator registers a downstream customization of the multus
+// admission controller render. It is not safe for concurrent use, so mutators should be registered
+// from init functions.
+func RegisterMultusAdmissionControllerRenderMutator(mutator RenderMutator) {
+	multusAdmissionControllerRenderMutators = append(multusAdmissionControllerRenderMutators, mutator)
+}
+
+// validateMultusAdmissionControllerUniqueObjects checks that no two rendered objects have the same
+// identity, since applying both would have them overwrite each other on every reconcile. Objects
+// of the same name in the management and the hosted cluster are distinct.
+func validateMultusAdmissionControllerUniqueObjects(objs []*uns.Unstructured) error {
+	seen := sets.New[string]()
+	duplicates := sets.New[string]()
+	for _, obj := range objs {
+		id := fmt.Sprintf("(%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		if cluster := obj.GetAnnotations()[names.ClusterNameAnnotation]; cluster != "" {
+			id = fmt.Sprintf("%s in cluster %s", id, cluster)
+		}
+		if seen.Has(id) {
+			duplicates.Insert(id)
+		}
+		seen.Insert(id)
+	}
+	if duplicates.Len() > 0 {
+		return fmt.Errorf("rendered duplicate multus admission controller objects: %s", strings.Join(sets.List(duplicates), ", "))
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerObjectSizes checks that no rendered object would exceed the
+// etcd request size limit, like a Deployment with a pathological ignored namespace list
+func validateMultusAdmissionControllerObjectSizes(objs []*uns.Unstructured) error {
+	for _, obj := range objs {
+		b, err := obj.MarshalJSON()
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		if len(b) > maxMultusAdmissionControllerObjectSize {
+			return fmt.Errorf("rendered %s %s/%s is %d bytes, exceeding the %d bytes limit", obj.GetKind(), obj.GetNamespace(), obj.GetName(),
+				len(b), maxMultusAdmissionControllerObjectSize)
+		}
+	}
+	return nil
+}
+
+// SplitMultusAdmissionControllerWebhookPhase splits the rendered objects into those the admission
+// controller needs to serve (phase 1) and the webhook configuration registering it (phase 2),
+// which must only be applied once the controller is serving
+func SplitMultusAdmissionControllerWebhookPhase(objs []*uns.Unstructured) ([]*uns.Unstructured, []*uns.Unstructured) {
+	phase1 := []*uns.Unstructured{}
+	phase2 := []*uns.Unstructured{}
+	for _, obj := range objs {
+		if obj.GetKind() == "ValidatingWebhookConfiguration" && obj.GetName() == names.MULTUS_VALIDATING_WEBHOOK {
+			phase2 = append(phase2, obj)
+		} else {
+			phase1 = append(phase1, obj)
+		}
+	}
+	return phase1, phase2
+}
+
+// getMultusAdmissionControllerNamespaceUID returns the first UID of the range the SCC allocated
+// to the admission controller namespace, which is in every pod's allowed range under the
+// restricted SCC
+func getMultusAdmissionControllerNamespaceUID(client cnoclient.Client, clusterName, name string) (int64, error) {
+	clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return 0, err
+	}
+	namespace := &corev1.Namespace{}
+	if err := clusterClient.CRClient().Get(context.TODO(), types.NamespacedName{Name: name}, namespace); err != nil {
+		return 0, fmt.Errorf("failed to get the multus admission controller namespace %s for run-as-user-source: %w", name, err)
+	}
+	uidRange, exists := namespace.Annotations[multusAdmissionControllerUIDRangeAnnotation]
+	if !exists {
+		return 0, fmt.Errorf("multus admission controller namespace %s has no %s annotation, required by run-as-user-source %q",
+			name, multusAdmissionControllerUIDRangeAnnotation, multusAdmissionControllerRunAsUserNamespaceRange)
+	}
+	first, size, found := strings.Cut(uidRange, "/")
+	uid, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || !found || uid <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q of namespace %s: must be <first UID>/<size>, with a non-root first UID",
+			multusAdmissionControllerUIDRangeAnnotation, uidRange, name)
+	}
+	if n, err := strconv.ParseInt(size, 10, 64); err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s annotation %q of namespace %s: must be <first UID>/<size>, with a positive size",
+			multusAdmissionControllerUIDRangeAnnotation, uidRange, name)
+	}
+	return uid, nil
+}
+
+// getMultusAdmissionControllerNodeZones returns the sorted zones of the nodes matching
+// nodeSelector, where the admission controller pods can run
+func getMultusAdmissionControllerNodeZones(client cnoclient.Client, clusterName string, nodeSelector map[string]string) ([]string, error) {
+	clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	nodes := &corev1.NodeList{}
+	if err := clusterClient.CRClient().List(context.TODO(), nodes, crclient.MatchingLabels(nodeSelector)); err != nil {
+		return nil, fmt.Errorf("failed to list the multus admission controller nodes for topology-mode: %w", err)
+	}
+	zones := sets.New[string]()
+	for _, node := range nodes.Items {
+		if zone := node.Labels[corev1.LabelTopologyZone]; zone != "" {
+			zones.Insert(zone)
+		}
+	}
+	return sets.List(zones), nil
+}
+
+// getMultusAdmissionControllerServiceCAHash returns the hash of the service CA in the
+// openshift-service-ca.crt ConfigMap of namespace, or "" until the service CA operator has
+// injected it
+func getMultusAdmissionControllerServiceCAHash(client cnoclient.Client, namespace, key string) (string, error) {
+	cm, err := client.Default().Kubernetes().CoreV1().ConfigMaps(namespace).Get(context.TODO(), "openshift-service-ca.crt", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get service CA %s/openshift-service-ca.crt: %w", namespace, err)
+	}
+	ca := cm.Data[key]
+	if ca == "" {
+		return "", nil
+	}
+	caHash := sha1.Sum([]byte(ca))
+	return hex.EncodeToString(caHash[:]), nil
+}
+
+// checkMultusAdmissionControllerRuntimeClass checks that the RuntimeClass the admission controller
+// pods run with exists in the cluster they are scheduled in, since pods referencing a missing
+// RuntimeClass are rejected at creation
+func checkMultusAdmissionControllerRuntimeClass(client cnoclient.Client, clusterName, name string) error {
+	clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return err
+	}
+	runtimeClass := &nodev1.RuntimeClass{}
+	err = clusterClient.CRClient().Get(context.TODO(), types.NamespacedName{Name: name}, runtimeClass)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("invalid runtime-class-name %q: RuntimeClass %s does not exist", name, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the multus admission controller RuntimeClass %s: %w", name, err)
+	}
+	return nil
+}
+
+// setMultusAdmissionControllerBackupAnnotations adds the backup annotations to the objects they
+// select, leaving the annotations the render set as they are
+func setMultusAdmissionControllerBackupAnnotations(objs []*uns.Unstructured, backupAnnotations []bootstrap.MultusAdmissionControllerObjectAnnotations) {
+	for _, selected := range backupAnnotations {
+		for _, obj := range objs {
+			if obj.GetKind() != selected.Kind || (selected.Name != "" && obj.GetName() != selected.Name) {
+				continue
+			}
+			anno := obj.GetAnnotations()
+			if anno == nil {
+				anno = map[string]string{}
+			}
+			for key, value := range selected.Annotations {
+				if _, exists := anno[key]; exists {
+					klog.Warningf("backup-annotations annotation %s is ignored on %s %s/%s, it is set by the render",
+						key, obj.GetKind(), obj.GetNamespace(), obj.GetName())
+					continue
+				}
+				anno[key] = value
+			}
+			obj.SetAnnotations(anno)
+		}
+	}
+}
+
+// setMultusAdmissionControllerWebhookRulesHash annotates the webhook configurations with a hash of
+// their webhooks rules, so that they are replaced rather than applied when the rules change
+func setMultusAdmissionControllerWebhookRulesHash(objs []*uns.Unstructured) error {
+	for _, obj := range objs {
+		if obj.GetKind() != "ValidatingWebhookConfiguration" {
+			continue
+		}
+		webhooks, _, err := uns.NestedSlice(obj.Object, "webhooks")
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the webhooks of %s", obj.GetName())
+		}
+		rules := map[string]interface{}{}
+		for _, webhook := range webhooks {
+			webhook, ok := webhook.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid webhook in %s", obj.GetName())
+			}
+			name, _ := webhook["name"].(string)
+			rules[name] = webhook["rules"]
+		}
+		// maps marshal with sorted keys, so the hash doesn't depend on the webhooks order
+		b, err := json.Marshal(rules)
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize the webhook rules of %s", obj.GetName())
+		}
+		hash := sha1.Sum(b)
+		anno := obj.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[names.ReplaceOnChangeAnnotation] = hex.EncodeToString(hash[:])
+		obj.SetAnnotations(anno)
+	}
+	return nil
+}
+
+// setMultusAdmissionControllerIgnoredNamespacesHash annotates the webhook configuration with a
+// hash of the ignored namespaces, so that tooling can tell when the ignore list changed without
+// parsing the admission controller arguments.
+func setMultusAdmissionControllerIgnoredNamespacesHash(objs []*uns.Unstructured, ignored string) {
+	hash := sha1.Sum([]byte(ignored))
+	for _, obj := range objs {
+		if obj.GetKind() != "ValidatingWebhookConfiguration" {
+			continue
+		}
+		anno := obj.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[multusAdmissionControllerIgnoredNamespacesHashAnnotation] = hex.EncodeToString(hash[:])
+		obj.SetAnnotations(anno)
+	}
+}
+
+// orderMultusAdmissionControllerWebhookRegistration moves the webhook configuration after the
+// controller objects. While deferred, until the controller endpoints of a fresh install are ready,
+// the webhook configuration is rendered with the create-wait annotation, so that it isn't created
+// and doesn't block NAD operations meanwhile. The registration is only deferred while the webhook
+// configuration doesn't exist, since create-wait also skips updates.
+func orderMultusAdmissionControllerWebhookRegistration(objs []*uns.Unstructured, deferred bool) []*uns.Unstructured {
+	phase1, phase2 := SplitMultusAdmissionControllerWebhookPhase(objs)
+	if deferred {
+		for _, obj := range phase2 {
+			anno := obj.GetAnnotations()
+			if anno == nil {
+				anno = map[string]string{}
+			}
+			anno[names.CreateWaitAnnotation] = "true"
+			obj.SetAnnotations(anno)
+		}
+	}
+	return append(phase1, phase2...)
+}
+
+// multusAdmissionControllerWebhookExists returns true if the multus admission controller
+// ValidatingWebhookConfiguration exists
+func multusAdmissionControllerWebhookExists(client cnoclient.Client) (bool, error) {
+	_, err := client.Default().Kubernetes().AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+		context.TODO(), names.MULTUS_VALIDATING_WEBHOOK, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get ValidatingWebhookConfiguration %s: %w", names.MULTUS_VALIDATING_WEBHOOK, err)
+	}
+	return true, nil
+}
+
+// multusAdmissionControllerEndpointsReady returns true if the admission controller Service has a
+// ready endpoint
+func multusAdmissionControllerEndpointsReady(client cnoclient.Client, clusterName, namespace string) (bool, error) {
+	clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return false, err
+	}
+	endpoints := &corev1.Endpoints{}
+	err = clusterClient.CRClient().Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "multus-admission-controller"}, endpoints)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get multus admission controller endpoints: %w", err)
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// renderMultusAdmissionControllerRevisions suffixes the admission controller Deployment name with
+// the configured revision, so that two revisions can run side by side behind the shared Service.
+// The previous revision Deployment is rendered with the create-wait annotation, which keeps it
+// running untouched, until it is pruned once the current revision is available.
+func renderMultusAdmissionControllerRevisions(objs []*uns.Unstructured, conf *bootstrap.MultusAdmissionControllerBootstrapResult, client cnoclient.Client, clusterName string) ([]*uns.Unstructured, error) {
+	out := make([]*uns.Unstructured, 0, len(objs)+1)
+	for _, obj := range objs {
+		if obj.GetKind() == "HorizontalPodAutoscaler" && obj.GetName() == "multus-admission-controller" {
+			// the autoscaler follows the current revision
+			hpa := obj.DeepCopy()
+			if err := uns.SetNestedField(hpa.Object, "multus-admission-controller-"+conf.Revision, "spec", "scaleTargetRef", "name"); err != nil {
+				return nil, errors.Wrapf(err, "failed to set the multus admission controller autoscaler revision %s", conf.Revision)
+			}
+			out = append(out, hpa)
+			continue
+		}
+		if obj.GetKind() != "Deployment" || obj.GetName() != "multus-admission-controller" {
+			out = append(out, obj)
+			continue
+		}
+
+		current := obj.DeepCopy()
+		if err := setMultusAdmissionControllerRevision(current, conf.Revision); err != nil {
+			return nil, err
+		}
+		out = append(out, current)
+
+		if conf.PreviousRevision == "" {
+			continue
+		}
+		if conf.PrunePreviousRevision {
+			available, err := multusAdmissionControllerDeploymentAvailable(client, clusterName, current.GetNamespace(), current.GetName())
+			if err != nil {
+				klog.Warningf("failed to get multus admission controller revision %s, retaining revision %s: %v", conf.Revision, conf.PreviousRevision, err)
+			}
+			if available {
+				klog.Infof("multus admission controller revision %s is available, pruning revision %s", conf.Revision, conf.PreviousRevision)
+				continue
+			}
+		}
+
+		previous := obj.DeepCopy()
+		if err := setMultusAdmissionControllerRevision(previous, conf.PreviousRevision); err != nil {
+			return nil, err
+		}
+		anno := previous.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[names.CreateWaitAnnotation] = "true"
+		previous.SetAnnotations(anno)
+		out = append(out, previous)
+	}
+	return out, nil
+}
+
+// setMultusAdmissionControllerRevision names and labels the admission controller Deployment for revision
+func setMultusAdmissionControllerRevision(obj *uns.Unstructured, revision string) error {
+	obj.SetName(obj.GetName() + "-" + revision)
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[multusAdmissionControllerRevisionLabel] = revision
+	obj.SetLabels(labels)
+
+	for _, fields := range [][]string{
+		{"spec", "selector", "matchLabels", multusAdmissionControllerRevisionLabel},
+		{"spec", "template", "metadata", "labels", multusAdmissionControllerRevisionLabel},
+	} {
+		if err := uns.SetNestedField(obj.Object, revision, fields...); err != nil {
+			return errors.Wrapf(err, "failed to set multus admission controller revision %s", revision)
+		}
+	}
+	return nil
+}
+
+// multusAdmissionControllerDeploymentAvailable returns true if all the replicas of the given
+// Deployment are updated and available, or a ManagementClusterUnavailableError if the operator has
+// no client for clusterName
+func multusAdmissionControllerDeploymentAvailable(client cnoclient.Client, clusterName, namespace, name string) (bool, error) {
+	clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+	if err != nil {
+		return false, err
+	}
+	deployment := &appsv1.Deployment{}
+	err = clusterClient.CRClient().Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, deployment)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas, nil
+}
+
+// ManagedGVKs returns the kinds of objects the multus admission controller render produces,
+// in any mode
+func ManagedGVKs() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		{Group: "", Version: "v1", Kind: "Namespace"},
+		{Group: "", Version: "v1", Kind: "Service"},
+		{Group: "", Version: "v1", Kind: "ServiceAccount"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"},
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"},
+		{Group: "monitoring.rhobs", Version: "v1", Kind: "ServiceMonitor"},
+		{Group: "template.openshift.io", Version: "v1", Kind: "Template"},
+	}
+}
+
+// FilterChangedMultusAdmissionControllerObjects tags the rendered objects with the hash of their
+// content and returns those whose content generation differs from the one in applied, keyed by
+// cluster/group/kind/namespace/name. It also returns the content generation of every object,
+// which the caller passes as applied on the next render once the returned objects are applied.
+func FilterChangedMultusAdmissionControllerObjects(objs []*uns.Unstructured, applied map[string]string) ([]*uns.Unstructured, map[string]string, error) {
+	changed := []*uns.Unstructured{}
+	generations := make(map[string]string, len(objs))
+	for _, obj := range objs {
+		// hash the content without any previous tag
+		anno := obj.GetAnnotations()
+		delete(anno, names.ContentGenerationAnnotation)
+		if len(anno) == 0 {
+			anno = nil
+		}
+		obj.SetAnnotations(anno)
+
+		generation, err := k8s.CalculateHash(obj.Object)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to hash %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[names.ContentGenerationAnnotation] = generation
+		obj.SetAnnotations(anno)
+
+		gvk := obj.GroupVersionKind()
+		key := strings.Join([]string{anno[names.ClusterNameAnnotation], gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()}, "/")
+		generations[key] = generation
+		if applied[key] != generation {
+			changed = append(changed, obj)
+		}
+	}
+	return changed, generations, nil
+}
+
+// multusAdmissionControllerServingCertSecretAnnotation has the service CA operator generate the
+// serving certificate Secret of a Service
+const multusAdmissionControllerServingCertSecretAnnotation = "service.alpha.openshift.io/serving-cert-secret-name"
+
+// multusAdmissionControllerServingCertGenerationErrorAnnotations are set on a Service by the service
+// CA operator when it fails to generate the serving certificate; it stops retrying after a number
+// of failures, until they are removed
+var multusAdmissionControllerServingCertGenerationErrorAnnotations = []string{
+	"service.alpha.openshift.io/serving-cert-generation-error",
+	"service.alpha.openshift.io/serving-cert-generation-error-num",
+	"service.beta.openshift.io/serving-cert-generation-error",
+	"service.beta.openshift.io/serving-cert-generation-error-num",
+}
+
+// multusAdmissionControllerServingSecretGracePeriod is how long the service CA operator is given
+// to generate the serving Secret of a new Service, before the Secret is reported missing
+const multusAdmissionControllerServingSecretGracePeriod = 5 * time.Minute
+
+// MultusAdmissionControllerServingSecretMissingError is returned when the serving certificate
+// Secret the service CA operator generates for the admission controller doesn't exist
+type MultusAdmissionControllerServingSecretMissingError struct {
+	Namespace string
+	Name      string
+}
+
+func (e *MultusAdmissionControllerServingSecretMissingError) Error() string {
+	return fmt.Sprintf("multus admission controller serving secret %s/%s does not exist", e.Namespace, e.Name)
+}
+
+// RecoverMultusAdmissionControllerServingSecret checks that the serving certificate Secret of the
+// rendered admission controller Service, named by its serving-cert-secret-name annotation, exists.
+// When it was deleted, it removes the generation error annotations of the Service, so that the
+// service CA operator generates the Secret again, and returns a
+// *MultusAdmissionControllerServingSecretMissingError. A Service younger than
+// multusAdmissionControllerServingSecretGracePeriod isn't checked.
+func RecoverMultusAdmissionControllerServingSecret(ctx context.Context, client cnoclient.Client, objs []*uns.Unstructured, now time.Time) error {
+	for _, obj := range objs {
+		if obj.GroupVersionKind() != corev1.SchemeGroupVersion.WithKind("Service") || obj.GetName() != "multus-admission-controller" {
+			continue
+		}
+		secretName := obj.GetAnnotations()[multusAdmissionControllerServingCertSecretAnnotation]
+		if secretName == "" {
+			continue
+		}
+		clusterName := obj.GetAnnotations()[names.ClusterNameAnnotation]
+		clusterClient, err := getMultusAdmissionControllerClusterClient(client, clusterName)
+		if err != nil {
+			return err
+		}
+		kubeClient := clusterClient.Kubernetes().CoreV1()
+		namespace := obj.GetNamespace()
+
+		service, err := kubeClient.Services(namespace).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get Service %s/%s: %w", namespace, obj.GetName(), err)
+		}
+		if now.Sub(service.CreationTimestamp.Time) < multusAdmissionControllerServingSecretGracePeriod {
+			continue
+		}
+		_, err = kubeClient.Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get serving secret %s/%s: %w", namespace, secretName, err)
+		}
+
+		reset := map[string]interface{}{}
+		for _, key := range multusAdmissionControllerServingCertGenerationErrorAnnotations {
+			if _, ok := service.Annotations[key]; ok {
+				reset[key] = nil
+			}
+		}
+		if len(reset) > 0 {
+			klog.Infof("multus admission controller serving secret %s/%s is missing, clearing the serving certificate generation errors of Service %s",
+				namespace, secretName, service.Name)
+			patch, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": reset}})
+			if err != nil {
+				return errors.Wrapf(err, "failed to serialize the Service %s/%s patch", namespace, service.Name)
+			}
+			if _, err := kubeClient.Services(namespace).Patch(ctx, service.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+				return fmt.Errorf("failed to clear the serving certificate generation errors of Service %s/%s: %w", namespace, service.Name, err)
+			}
+		}
+		return &MultusAdmissionControllerServingSecretMissingError{Namespace: namespace, Name: secretName}
+	}
+	return nil
+}
+
+// VerifyMultusAdmissionControllerCA performs a TLS handshake with the multus admission controller
+// webhook URL, trusting only the caBundle of the rendered ValidatingWebhookConfiguration. This only
+// applies to HyperShift, where the caBundle is rendered rather than injected. If the webhook serves
+// a certificate not signed by the rendered CA, the returned error wraps a
+// *tls.CertificateVerificationError, distinguishing CA problems from an unreachable controller.
+// The handshake is bounded by the ctx deadline.
+func VerifyMultusAdmissionControllerCA(ctx context.Context, objs []*uns.Unstructured) error {
+	for _, obj := range objs {
+		if obj.GetKind() != "ValidatingWebhookConfiguration" || obj.GetName() != names.MULTUS_VALIDATING_WEBHOOK {
+			continue
+		}
+		webhooks, _, err := uns.NestedSlice(obj.Object, "webhooks")
+		if err != nil {
+			return fmt.Errorf("failed to get webhooks of %s: %w", obj.GetName(), err)
+		}
+		for _, webhook := range webhooks {
+			w, ok := webhook.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			webhookURL, _, _ := uns.NestedString(w, "clientConfig", "url")
+			caBundle, _, _ := uns.NestedString(w, "clientConfig", "caBundle")
+			if webhookURL == "" || caBundle == "" {
+				continue
+			}
+			if err := verifyWebhookCA(ctx, webhookURL, caBundle); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verifyWebhookCA performs a TLS handshake with webhookURL trusting only the encoded caBundle
+func verifyWebhookCA(ctx context.Context, webhookURL, caBundle string) error {
+	ca, err := base64.URLEncoding.DecodeString(caBundle)
+	if err != nil {
+		return fmt.Errorf("failed to decode caBundle for %s: %w", webhookURL, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("caBundle for %s contains no certificates", webhookURL)
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook url %s: %w", webhookURL, err)
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			RootCAs:    pool,
+			ServerName: u.Hostname(),
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("TLS handshake with %s failed: %w", webhookURL, err)
+	}
+	return conn.Close()
+}
+
+// MultusAdmissionControllerRenderDecision is whether the multus admission controller render includes
+// one of its conditional objects, and why
+type MultusAdmissionControllerRenderDecision struct {
+	Kind     string
+	Name     string
+	Included bool
+	Reason   string
+}
+
+// ExplainMultusAdmissionControllerRender returns the decisions the multus admission controller
+// render takes on its conditional objects. It doesn't render: the decisions are derived from the
+// bootstrap result with the predicates the render gates the objects with, so explaining has no
+// side effects, and explains a configuration the render would reject too. Only the cluster
+// capabilities and state the decisions depend on are looked up.
+func ExplainMultusAdmissionControllerRender(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client) ([]MultusAdmissionControllerRenderDecision, error) {
+	if conf.DisableMultiNetwork != nil && *conf.DisableMultiNetwork {
+		return []MultusAdmissionControllerRenderDecision{{
+			Kind: "Deployment", Name: "multus-admission-controller", Reason: "disableMultiNetwork is set",
+		}}, nil
+	}
+	if bootstrapResult == nil {
+		return nil, fmt.Errorf("cannot explain multus admission controller render: bootstrap result is missing")
+	}
+	return append([]MultusAdmissionControllerRenderDecision{{
+		Kind: "Deployment", Name: "multus-admission-controller", Included: true, Reason: "disableMultiNetwork is not set",
+	}}, multusAdmissionControllerRenderDecisions(&bootstrapResult.MultusAdmissionController, client)...), nil
+}
+
+// multusAdmissionControllerRenderDecisions returns the decisions on the conditional objects the
+// render takes with the configuration conf, explained with the options they are derived from. A
+// lookup that fails is explained rather than returned.
+func multusAdmissionControllerRenderDecisions(conf *bootstrap.MultusAdmissionControllerBootstrapResult, client cnoclient.Client) []MultusAdmissionControllerRenderDecision {
+	var decisions []MultusAdmissionControllerRenderDecision
+	decide := func(kind, name string, included bool, reason string) {
+		decisions = append(decisions, MultusAdmissionControllerRenderDecision{Kind: kind, Name: name, Included: included, Reason: reason})
+	}
+	hsc := multusAdmissionControllerHyperShiftConfig(conf)
+	namespace := multusAdmissionControllerNamespaceName(hsc)
+
+	switch {
+	case multusAdmissionControllerRendersNamespace(conf, hsc):
+		decide("Namespace", namespace, true, fmt.Sprintf("namespace-policy is %s", multusAdmissionControllerNamespaceRender))
+	case !hsc.Enabled:
+		decide("Namespace", namespace, false, "outside of HyperShift the namespace is rendered with multus")
+	default:
+		decide("Namespace", namespace, false,
+			fmt.Sprintf("namespace-policy is %s, the namespace must exist in the management cluster", multusAdmissionControllerNamespaceRequireExists))
+	}
+
+	if conf.SplitRBAC {
+		decide("ClusterRole", "multus-admission-controller-webhook-writer", true, "split-rbac is set")
+	} else {
+		decide("ClusterRole", "multus-admission-controller-webhook-writer", false,
+			"split-rbac is not set, the write permissions are in multus-admission-controller-webhook")
+	}
+	if conf.RoleAggregation {
+		decide("ClusterRole", "multus-admission-controller-webhook-aggregated", true, "role-aggregation is set")
+	} else {
+		decide("ClusterRole", "multus-admission-controller-webhook-aggregated", false, "role-aggregation is not set")
+	}
+
+	autoscaling, err := multusAdmissionControllerAutoscaling(conf, client)
+	switch {
+	case err != nil:
+		decide("HorizontalPodAutoscaler", "multus-admission-controller", false, fmt.Sprintf("autoscaling is set, but %v", err))
+	case autoscaling:
+		decide("HorizontalPodAutoscaler", "multus-admission-controller", true, "autoscaling is set")
+	case conf.Autoscaling:
+		decide("HorizontalPodAutoscaler", "multus-admission-controller", false,
+			fmt.Sprintf("autoscaling is set, but %s horizontalpodautoscalers are not served by the cluster", autoscalingv2.SchemeGroupVersion))
+	default:
+		decide("HorizontalPodAutoscaler", "multus-admission-controller", false, "autoscaling is not set")
+	}
+
+	clusterName := multusAdmissionControllerClusterName(hsc)
+	deferred, err := multusAdmissionControllerWebhookRegistrationDeferred(conf, client, clusterName, namespace)
+	switch {
+	case err != nil:
+		decide("ValidatingWebhookConfiguration", names.MULTUS_VALIDATING_WEBHOOK, true,
+			fmt.Sprintf("webhook-registration is %s, but whether it is created can't be decided: %v",
+				multusAdmissionControllerWebhookRegistrationAfterReady, err))
+	case deferred:
+		decide("ValidatingWebhookConfiguration", names.MULTUS_VALIDATING_WEBHOOK, true,
+			fmt.Sprintf("webhook-registration is %s and the controller endpoints are not ready, it is rendered with the create-wait annotation and not created",
+				multusAdmissionControllerWebhookRegistrationAfterReady))
+	case conf.WebhookRegistration == multusAdmissionControllerWebhookRegistrationAfterReady:
+		decide("ValidatingWebhookConfiguration", names.MULTUS_VALIDATING_WEBHOOK, true,
+			fmt.Sprintf("webhook-registration is %s and the controller endpoints are ready or the webhook configuration exists",
+				multusAdmissionControllerWebhookRegistrationAfterReady))
+	default:
+		decide("ValidatingWebhookConfiguration", names.MULTUS_VALIDATING_WEBHOOK, true,
+			fmt.Sprintf("webhook-registration is %s", multusAdmissionControllerWebhookRegistrationImmediate))
+	}
+
+	if conf.MetricsService {
+		decide("Service", "multus-admission-controller-metrics", true, "metrics-service is set, the metrics are not served by the webhook Service")
+	} else {
+		decide("Service", "multus-admission-controller-metrics", false, "metrics-service is not set, the webhook Service serves the metrics")
+	}
+	switch multusAdmissionControllerMonitoring(conf.DisableServiceMonitor, os.Getenv("RHOBS_MONITORING")) {
+	case multusAdmissionControllerMonitoringDisabled:
+		decide("ServiceMonitor", "monitor-multus-admission-controller", false, "disable-service-monitor is set")
+	case multusAdmissionControllerMonitoringRHOBS:
+		decide("ServiceMonitor", "monitor-multus-admission-controller", true, "RHOBS_MONITORING is set, the ServiceMonitor is a monitoring.rhobs/v1 one")
+	default:
+		decide("ServiceMonitor", "monitor-multus-admission-controller", true, "disable-service-monitor is not set")
+	}
+	if hsc.Enabled {
+		decide("Role", "prometheus-k8s", false, "in HyperShift the metrics are scraped by the management cluster monitoring")
+	} else {
+		decide("Role", "prometheus-k8s", true, "cluster monitoring scrapes the metrics outside of HyperShift")
+	}
+	decide("PrometheusRule", "prometheus-k8s-rules", true, "the recording rules are always rendered, and their apply errors ignored")
+
+	if multusAdmissionControllerOutputFormat(conf) == multusAdmissionControllerOutputFormatTemplate {
+		decide("Template", "multus-admission-controller", true,
+			fmt.Sprintf("output-format is %s, the other objects are wrapped in it", multusAdmissionControllerOutputFormatTemplate))
+	} else {
+		decide("Template", "multus-admission-controller", false,
+			fmt.Sprintf("output-format is %s, the objects are rendered themselves", multusAdmissionControllerOutputFormatRaw))
+	}
+	return decisions
+}
+
+// multusAdmissionControllerHyperShiftConfig returns the HyperShift configuration of conf, a
+// disabled one when there is none
+func multusAdmissionControllerHyperShiftConfig(conf *bootstrap.MultusAdmissionControllerBootstrapResult) *bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult {
+	if conf.HyperShiftConfig == nil {
+		return &bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult{}
+	}
+	return conf.HyperShiftConfig
+}
+
+// multusAdmissionControllerNamespaceName returns the namespace the admission controller runs in,
+// the hosted control plane one in HyperShift
+func multusAdmissionControllerNamespaceName(hsc *bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult) string {
+	if hsc.Enabled {
+		return hsc.Namespace
+	}
+	return "openshift-multus"
+}
+
+// multusAdmissionControllerRendersNamespace returns whether the admission controller renders its
+// namespace, which it only does in HyperShift with the render namespace-policy
+func multusAdmissionControllerRendersNamespace(conf *bootstrap.MultusAdmissionControllerBootstrapResult, hsc *bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult) bool {
+	return hsc.Enabled && conf.NamespacePolicy == multusAdmissionControllerNamespaceRender
+}
+
+// multusAdmissionControllerAutoscaling returns whether the HorizontalPodAutoscaler is rendered:
+// autoscaling is set and the cluster serves it
+func multusAdmissionControllerAutoscaling(conf *bootstrap.MultusAdmissionControllerBootstrapResult, client cnoclient.Client) (bool, error) {
+	if !conf.Autoscaling {
+		return false, nil
+	}
+	return multusAdmissionControllerHPAsServed(client)
+}
+
+// multusAdmissionControllerWebhookRegistrationDeferred returns whether the webhook configuration
+// isn't created yet: webhook-registration is AfterReady, the controller endpoints in namespace
+// of clusterName are not ready and the webhook configuration doesn't exist. Only the creation is
+// held back, an existing webhook configuration keeps being updated.
+func multusAdmissionControllerWebhookRegistrationDeferred(conf *bootstrap.MultusAdmissionControllerBootstrapResult, client cnoclient.Client, clusterName, namespace string) (bool, error) {
+	if conf.WebhookRegistration != multusAdmissionControllerWebhookRegistrationAfterReady {
+		return false, nil
+	}
+	ready, err := multusAdmissionControllerEndpointsReady(client, clusterName, namespace)
+	if err != nil || ready {
+		return false, err
+	}
+	exists, err := multusAdmissionControllerWebhookExists(client)
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// multusAdmissionControllerOutputFormat returns the output-format of conf, raw by default
+func multusAdmissionControllerOutputFormat(conf *bootstrap.MultusAdmissionControllerBootstrapResult) string {
+	if conf.OutputFormat == "" {
+		return multusAdmissionControllerOutputFormatRaw
+	}
+	return conf.OutputFormat
+}
+
+// Monitoring of the rendered admission controller
+const (
+	multusAdmissionControllerMonitoringClusterMonitoring = "cluster-monitoring"
+	multusAdmissionControllerMonitoringRHOBS             = "rhobs"
+	multusAdmissionControllerMonitoringDisabled          = "disabled"
+)
+
+// multusAdmissionControllerMonitoring returns how the admission controller is monitored with
+// disable-service-monitor and the RHOBS_MONITORING environment, as the monitor template decides it
+func multusAdmissionControllerMonitoring(disableServiceMonitor bool, rhobsMonitoring string) string {
+	switch {
+	case disableServiceMonitor:
+		return multusAdmissionControllerMonitoringDisabled
+	case rhobsMonitoring == "1":
+		return multusAdmissionControllerMonitoringRHOBS
+	default:
+		return multusAdmissionControllerMonitoringClusterMonitoring
+	}
+}
+
+// multusAdmissionControllerCABundleFingerprintLength is the number of hex digits of the caBundle
+// SHA-256 digest a fingerprint keeps, enough to tell CAs apart at a glance
+const multusAdmissionControllerCABundleFingerprintLength = 16
+
+// MultusAdmissionControllerCABundleFingerprint returns a short SHA-256 fingerprint of the caBundle of
+// the rendered multus webhook configuration, to report which CA the webhook trusts. It returns an
+// empty fingerprint when the render has no caBundle, which the service CA injects then.
+func MultusAdmissionControllerCABundleFingerprint(objs []*uns.Unstructured) (string, error) {
+	for _, obj := range objs {
+		if obj.GetKind() != "ValidatingWebhookConfiguration" || obj.GetName() != names.MULTUS_VALIDATING_WEBHOOK {
+			continue
+		}
+		webhooks, _, err := uns.NestedSlice(obj.Object, "webhooks")
+		if err != nil || len(webhooks) == 0 {
+			return "", fmt.Errorf("invalid ValidatingWebhookConfiguration %s: no webhooks", obj.GetName())
+		}
+		webhook, ok := webhooks[0].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("invalid ValidatingWebhookConfiguration %s: webhooks[0] is not an object", obj.GetName())
+		}
+		encoded, _, err := uns.NestedString(webhook, "clientConfig", "caBundle")
+		if err != nil {
+			return "", fmt.Errorf("invalid ValidatingWebhookConfiguration %s caBundle: %w", obj.GetName(), err)
+		}
+		if encoded == "" {
+			return "", nil
+		}
+		// the render encodes the caBundle with the URL alphabet, the API server with the standard one
+		ca, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			if ca, err = base64.URLEncoding.DecodeString(encoded); err != nil {
+				return "", fmt.Errorf("invalid ValidatingWebhookConfiguration %s caBundle: %w", obj.GetName(), err)
+			}
+		}
+		digest := sha256.Sum256(ca)
+		return hex.EncodeToString(digest[:])[:multusAdmissionControllerCABundleFingerprintLength], nil
+	}
+	return "", nil
+}
+
+// multusAdmissionControllerRenderSummary returns the key decisions of a render as klog key/value
+// pairs, so that a single log line describes the rendered admission controller
+func multusAdmissionControllerRenderSummary(data *render.RenderData, objects int) []interface{} {
+	rhobsMonitoring, _ := data.Data["RHOBSMonitoring"].(string)
+	namespaces := 0
+	if ignored, _ := data.Data["IgnoredNamespace"].(string); ignored != "" {
+		namespaces = len(strings.Split(ignored, ","))
+	}
+	summary := []interface{}{
+		"objects", objects,
+		"replicas", data.Data["Replicas"],
+		"failurePolicy", data.Data["FailurePolicy"],
+		"hyperShift", data.Data["HyperShiftEnabled"],
+		"namespace", data.Data["AdmissionControllerNamespace"],
+		"ignoredNamespaces", namespaces,
+		"monitoring", multusAdmissionControllerMonitoring(data.Data["DisableServiceMonitor"] == true, rhobsMonitoring),
+		"kubeRBACProxy", data.Data["KubeRBACProxyEnabled"],
+		"image", data.Data["MultusAdmissionControllerImage"],
+		"kubeRBACProxyImage", data.Data["KubeRBACProxyImage"],
+	}
+	if hyperShift, _ := data.Data["HyperShiftEnabled"].(bool); hyperShift {
+		summary = append(summary,
+			"tokenMinterImage", data.Data["TokenMinterImage"],
+			"cliImage", data.Data["CLIImage"])
+	}
+	return summary
 }
@@ -0,0 +1,857 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	cnoclient "github.com/openshift/cluster-network-operator/pkg/client"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/platform"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// bootstrapMultusAdmissionController returns the HyperShift configuration and the values in the
+// openshift-network-operator/multus-admission-controller-config ConfigMap, if it exists
+func bootstrapMultusAdmissionController(client cnoclient.Client) (*bootstrap.MultusAdmissionControllerBootstrapResult, error) {
+	hsc := platform.NewHyperShiftConfig()
+	result := &bootstrap.MultusAdmissionControllerBootstrapResult{
+		HyperShiftConfig: &bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult{
+			Enabled:      hsc.Enabled,
+			Namespace:    hsc.Namespace,
+			RunAsUser:    hsc.RunAsUser,
+			ReleaseImage: hsc.ReleaseImage,
+		},
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := client.ClientFor("").CRClient().Get(context.TODO(), types.NamespacedName{
+		Namespace: names.APPLIED_NAMESPACE,
+		Name:      names.MULTUS_ADMISSION_CONTROLLER_CONFIG,
+	}, cm)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("could not get %s configmap: %w", names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		return result, nil
+	}
+
+	if tokenMountPath, exists := cm.Data["token-mount-path"]; exists {
+		result.TokenMountPath = tokenMountPath
+	}
+	if auditLogDir, exists := cm.Data["audit-log-dir"]; exists {
+		result.AuditLogDir = auditLogDir
+	}
+	if auditLogHostPath, exists := cm.Data["audit-log-host-path"]; exists {
+		result.AuditLogHostPath = auditLogHostPath
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "ipam-hints", &result.IPAMHints); err != nil {
+		return nil, err
+	}
+	if result.LogLevel, err = parseMultusAdmissionControllerConfigInt(cm.Data, "log-level"); err != nil {
+		return nil, err
+	}
+	if result.WebhookWorkerCount, err = parseMultusAdmissionControllerConfigInt(cm.Data, "webhook-worker-count"); err != nil {
+		return nil, err
+	}
+	if metricsAuth, exists := cm.Data["metrics-auth"]; exists {
+		result.MetricsAuth = metricsAuth
+	}
+	if namespacePolicy, exists := cm.Data["namespace-policy"]; exists {
+		result.NamespacePolicy = namespacePolicy
+	}
+	if sessionAffinity, exists := cm.Data["session-affinity"]; exists {
+		result.SessionAffinity = corev1.ServiceAffinity(sessionAffinity)
+	}
+	if result.SessionAffinityTimeout, err = parseMultusAdmissionControllerConfigInt(cm.Data, "session-affinity-timeout"); err != nil {
+		return nil, err
+	}
+	if result.EphemeralStorageRequest, err = parseMultusAdmissionControllerConfigQuantity(cm.Data, "ephemeral-storage-request"); err != nil {
+		return nil, err
+	}
+	if result.EphemeralStorageLimit, err = parseMultusAdmissionControllerConfigQuantity(cm.Data, "ephemeral-storage-limit"); err != nil {
+		return nil, err
+	}
+	if result.ScratchVolumeSizeLimit, err = parseMultusAdmissionControllerConfigQuantity(cm.Data, "scratch-volume-size-limit"); err != nil {
+		return nil, err
+	}
+	if topologyMode, exists := cm.Data["topology-mode"]; exists {
+		result.TopologyMode = topologyMode
+	}
+	if trafficPolicy, exists := cm.Data["internal-traffic-policy"]; exists {
+		result.InternalTrafficPolicy = corev1.ServiceInternalTrafficPolicy(trafficPolicy)
+	}
+	if dnsPolicy, exists := cm.Data["dns-policy"]; exists {
+		result.DNSPolicy = corev1.DNSPolicy(dnsPolicy)
+	}
+	result.DNSNameservers = parseMultusAdmissionControllerConfigList(cm.Data, "dns-nameservers")
+	result.DNSSearches = parseMultusAdmissionControllerConfigList(cm.Data, "dns-searches")
+	result.AdditionalCNIPlugins = parseMultusAdmissionControllerConfigList(cm.Data, "additional-cni-plugins")
+	result.AdmissionReviewVersions = parseMultusAdmissionControllerConfigList(cm.Data, "admission-review-versions")
+	if revision, exists := cm.Data["revision"]; exists {
+		result.Revision = revision
+	}
+	if previousRevision, exists := cm.Data["previous-revision"]; exists {
+		result.PreviousRevision = previousRevision
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "prune-previous-revision", &result.PrunePreviousRevision); err != nil {
+		return nil, err
+	}
+	if result.AutomountServiceAccountToken, err = parseMultusAdmissionControllerConfigOptionalBool(cm.Data, "automount-service-account-token"); err != nil {
+		return nil, err
+	}
+	if result.MeshInjection, err = parseMultusAdmissionControllerConfigOptionalBool(cm.Data, "mesh-injection"); err != nil {
+		return nil, err
+	}
+	if result.MetricsPort, err = parseMultusAdmissionControllerConfigInt(cm.Data, "metrics-port"); err != nil {
+		return nil, err
+	}
+	if result.ProgressDeadlineSeconds, err = parseMultusAdmissionControllerConfigInt(cm.Data, "progress-deadline-seconds"); err != nil {
+		return nil, err
+	}
+	if result.RevisionHistoryLimit, err = parseMultusAdmissionControllerConfigInt(cm.Data, "revision-history-limit"); err != nil {
+		return nil, err
+	}
+	if result.FSGroup, err = parseMultusAdmissionControllerConfigInt(cm.Data, "fs-group"); err != nil {
+		return nil, err
+	}
+	for _, group := range parseMultusAdmissionControllerConfigList(cm.Data, "supplemental-groups") {
+		gid, err := strconv.Atoi(group)
+		if err != nil {
+			return nil, fmt.Errorf("invalid supplemental-groups value %q in %s configmap: %w", cm.Data["supplemental-groups"],
+				names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.SupplementalGroups = append(result.SupplementalGroups, gid)
+	}
+	if refreshInterval, exists := cm.Data["ignored-namespaces-refresh-interval"]; exists {
+		d, err := time.ParseDuration(refreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignored-namespaces-refresh-interval value %q in %s configmap: %w",
+				refreshInterval, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.IgnoredNamespacesRefreshInterval = &d
+	}
+	if gracePeriod, exists := cm.Data["ignored-namespaces-grace-period"]; exists {
+		d, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignored-namespaces-grace-period value %q in %s configmap: %w",
+				gracePeriod, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.IgnoredNamespacesGracePeriod = &d
+	}
+	if reconcileInterval, exists := cm.Data["reconcile-interval"]; exists {
+		d, err := time.ParseDuration(reconcileInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reconcile-interval value %q in %s configmap: %w",
+				reconcileInterval, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.ReconcileInterval = &d
+	}
+	if requestTimeout, exists := cm.Data["token-minter-request-timeout"]; exists {
+		d, err := time.ParseDuration(requestTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token-minter-request-timeout value %q in %s configmap: %w",
+				requestTimeout, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.TokenMinterRequestTimeout = &d
+	}
+	if shutdownTimeout, exists := cm.Data["shutdown-timeout"]; exists {
+		d, err := time.ParseDuration(shutdownTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shutdown-timeout value %q in %s configmap: %w",
+				shutdownTimeout, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+		result.ShutdownTimeout = &d
+	}
+	if hostAliases, exists := cm.Data["host-aliases"]; exists {
+		if err := json.Unmarshal([]byte(hostAliases), &result.HostAliases); err != nil {
+			return nil, fmt.Errorf("invalid host-aliases value %q in %s configmap: must be a JSON list of host aliases: %w",
+				hostAliases, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if objectSelector, exists := cm.Data["webhook-object-selector"]; exists {
+		result.WebhookObjectSelector = objectSelector
+	}
+	if extraWebhookRules, exists := cm.Data["extra-webhook-rules"]; exists {
+		if err := json.Unmarshal([]byte(extraWebhookRules), &result.ExtraWebhookRules); err != nil {
+			return nil, fmt.Errorf("invalid extra-webhook-rules value %q in %s configmap: must be a JSON list of webhook rules: %w",
+				extraWebhookRules, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if extraEnv, exists := cm.Data["extra-env"]; exists {
+		if err := json.Unmarshal([]byte(extraEnv), &result.ExtraEnv); err != nil {
+			return nil, fmt.Errorf("invalid extra-env value %q in %s configmap: must be a JSON list of environment variables: %w",
+				extraEnv, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if backupAnnotations, exists := cm.Data["backup-annotations"]; exists {
+		if err := json.Unmarshal([]byte(backupAnnotations), &result.BackupAnnotations); err != nil {
+			return nil, fmt.Errorf("invalid backup-annotations value %q in %s configmap: must be a JSON list of object annotations: %w",
+				backupAnnotations, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if imageDigests, exists := cm.Data["image-digests"]; exists {
+		if err := json.Unmarshal([]byte(imageDigests), &result.ImageDigests); err != nil {
+			return nil, fmt.Errorf("invalid image-digests value %q in %s configmap: must be a JSON object of image references to digests: %w",
+				imageDigests, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if apiServer, exists := cm.Data["token-minter-api-server"]; exists {
+		result.TokenMinterAPIServer = apiServer
+	}
+	if fallbackImage, exists := cm.Data["kube-rbac-proxy-fallback-image"]; exists {
+		result.KubeRBACProxyFallbackImage = fallbackImage
+	}
+	if metricsPath, exists := cm.Data["metrics-path"]; exists {
+		result.MetricsPath = metricsPath
+	}
+	if sideEffects, exists := cm.Data["side-effects"]; exists {
+		result.SideEffects = admissionregistrationv1.SideEffectClass(sideEffects)
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "paused", &result.Paused); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "autoscaling", &result.Autoscaling); err != nil {
+		return nil, err
+	}
+	if failurePolicy, exists := cm.Data["failure-policy"]; exists {
+		result.FailurePolicy = admissionregistrationv1.FailurePolicyType(failurePolicy)
+	}
+	if pausedFailurePolicy, exists := cm.Data["paused-failure-policy"]; exists {
+		result.PausedFailurePolicy = admissionregistrationv1.FailurePolicyType(pausedFailurePolicy)
+	}
+	for key, out := range map[string]**int{
+		"probe-initial-delay-seconds": &result.ProbeInitialDelaySeconds,
+		"probe-period-seconds":        &result.ProbePeriodSeconds,
+		"probe-timeout-seconds":       &result.ProbeTimeoutSeconds,
+		"probe-failure-threshold":     &result.ProbeFailureThreshold,
+
+		"startup-probe-period-seconds":    &result.StartupProbePeriodSeconds,
+		"startup-probe-failure-threshold": &result.StartupProbeFailureThreshold,
+
+		"pre-stop-sleep-seconds": &result.PreStopSleepSeconds,
+
+		"autoscaling-min-replicas":       &result.AutoscalingMinReplicas,
+		"autoscaling-max-replicas":       &result.AutoscalingMaxReplicas,
+		"autoscaling-cpu-utilization":    &result.AutoscalingCPUUtilization,
+		"autoscaling-memory-utilization": &result.AutoscalingMemoryUtilization,
+	} {
+		if *out, err = parseMultusAdmissionControllerConfigInt(cm.Data, key); err != nil {
+			return nil, err
+		}
+	}
+	if runtimeClassName, exists := cm.Data["runtime-class-name"]; exists {
+		result.RuntimeClassName = runtimeClassName
+	}
+	if seccompProfileType, exists := cm.Data["seccomp-profile-type"]; exists {
+		result.SeccompProfileType = corev1.SeccompProfileType(seccompProfileType)
+	}
+	if seccompLocalhostProfile, exists := cm.Data["seccomp-localhost-profile"]; exists {
+		result.SeccompLocalhostProfile = seccompLocalhostProfile
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "multus-affinity", &result.MultusAffinity); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "role-aggregation", &result.RoleAggregation); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "split-rbac", &result.SplitRBAC); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "ignored-namespaces-hash", &result.IgnoredNamespacesHash); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "rejection-events", &result.RejectionEvents); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "require-cluster-id", &result.RequireClusterID); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "disable-service-monitor", &result.DisableServiceMonitor); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "metrics-service", &result.MetricsService); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "health-probes", &result.HealthProbes); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "schema-validation", &result.SchemaValidation); err != nil {
+		return nil, err
+	}
+	if err := parseMultusAdmissionControllerConfigBool(cm.Data, "development-mode", &result.DevelopmentMode); err != nil {
+		return nil, err
+	}
+	if commandOverride, exists := cm.Data["command-override"]; exists {
+		if err := json.Unmarshal([]byte(commandOverride), &result.CommandOverride); err != nil {
+			return nil, fmt.Errorf("invalid command-override value %q in %s configmap: must be a JSON list of strings: %w",
+				commandOverride, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+		}
+	}
+	if strategy, exists := cm.Data["namespace-selector-strategy"]; exists {
+		result.NamespaceSelectorStrategy = strategy
+	}
+	if webhookRegistration, exists := cm.Data["webhook-registration"]; exists {
+		result.WebhookRegistration = webhookRegistration
+	}
+	if runAsUserSource, exists := cm.Data["run-as-user-source"]; exists {
+		result.RunAsUserSource = runAsUserSource
+	}
+	if matchPolicy, exists := cm.Data["webhook-match-policy"]; exists {
+		result.WebhookMatchPolicy = admissionregistrationv1.MatchPolicyType(matchPolicy)
+	}
+	if webhookUpdateStrategy, exists := cm.Data["webhook-update-strategy"]; exists {
+		result.WebhookUpdateStrategy = webhookUpdateStrategy
+	}
+	if outputFormat, exists := cm.Data["output-format"]; exists {
+		result.OutputFormat = outputFormat
+	}
+	if certReloadStrategy, exists := cm.Data["cert-reload-strategy"]; exists {
+		result.CertReloadStrategy = certReloadStrategy
+	}
+	if imagePullPolicy, exists := cm.Data["image-pull-policy"]; exists {
+		result.ImagePullPolicy = corev1.PullPolicy(imagePullPolicy)
+	}
+	if serviceCAKey, exists := cm.Data["service-ca-key"]; exists {
+		result.ServiceCAKey = serviceCAKey
+	}
+	if servingCertSecret, exists := cm.Data["serving-cert-secret"]; exists {
+		result.ServingCertSecret = servingCertSecret
+	}
+	if serviceAccountName, exists := cm.Data["service-account-name"]; exists {
+		result.ServiceAccountName = serviceAccountName
+	}
+	if maxUnavailable, exists := cm.Data["max-unavailable"]; exists {
+		v := intstr.Parse(maxUnavailable)
+		result.MaxUnavailable = &v
+	}
+	if maxSurge, exists := cm.Data["max-surge"]; exists {
+		v := intstr.Parse(maxSurge)
+		result.MaxSurge = &v
+	}
+
+	return result, nil
+}
+
+// parseMultusAdmissionControllerConfigBool sets out to the boolean value of key, if it exists
+func parseMultusAdmissionControllerConfigBool(data map[string]string, key string, out *bool) error {
+	value, exists := data[key]
+	if !exists {
+		return nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q in %s configmap: %w", key, value, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+	}
+	*out = b
+	return nil
+}
+
+// parseMultusAdmissionControllerConfigOptionalBool returns the boolean value of key, or nil if it doesn't exist
+func parseMultusAdmissionControllerConfigOptionalBool(data map[string]string, key string) (*bool, error) {
+	if _, exists := data[key]; !exists {
+		return nil, nil
+	}
+	var b bool
+	if err := parseMultusAdmissionControllerConfigBool(data, key, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// parseMultusAdmissionControllerConfigInt returns the integer value of key, or nil if it doesn't exist
+func parseMultusAdmissionControllerConfigInt(data map[string]string, key string) (*int, error) {
+	value, exists := data[key]
+	if !exists {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q in %s configmap: %w", key, value, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+	}
+	return &i, nil
+}
+
+// parseMultusAdmissionControllerConfigQuantity returns the resource quantity of key, or nil if it doesn't exist
+func parseMultusAdmissionControllerConfigQuantity(data map[string]string, key string) (*resource.Quantity, error) {
+	value, exists := data[key]
+	if !exists {
+		return nil, nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q in %s configmap: %w", key, value, names.MULTUS_ADMISSION_CONTROLLER_CONFIG, err)
+	}
+	return &q, nil
+}
+
+// parseMultusAdmissionControllerConfigList returns the comma separated values of key, or nil if it doesn't exist
+func parseMultusAdmissionControllerConfigList(data map[string]string, key string) []string {
+	var out []string
+	for _, value := range strings.Split(data[key], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// EffectiveMultusAdmissionControllerFailurePolicy returns the failurePolicy of the admission
+// controller webhook. In order of precedence, it is:
+//   - while paused, paused-failure-policy, or Ignore if unset, since a paused admission controller
+//     runs no pods and the webhook would otherwise reject every NetworkAttachmentDefinition
+//   - failure-policy
+//   - Fail, the API default
+func EffectiveMultusAdmissionControllerFailurePolicy(conf *bootstrap.MultusAdmissionControllerBootstrapResult) admissionregistrationv1.FailurePolicyType {
+	if conf.Paused {
+		if conf.PausedFailurePolicy != "" {
+			return conf.PausedFailurePolicy
+		}
+		return admissionregistrationv1.Ignore
+	}
+	if conf.FailurePolicy != "" {
+		return conf.FailurePolicy
+	}
+	return admissionregistrationv1.Fail
+}
+
+// validateMultusAdmissionControllerAuditLogDir checks that the audit log directory is an absolute
+// path that doesn't overlap the other mounts of the admission controller container
+func validateMultusAdmissionControllerAuditLogDir(conf *bootstrap.MultusAdmissionControllerBootstrapResult) error {
+	if !path.IsAbs(conf.AuditLogDir) || path.Clean(conf.AuditLogDir) == "/" {
+		return fmt.Errorf("invalid audit-log-dir %q: must be an absolute path other than /", conf.AuditLogDir)
+	}
+	dir := path.Clean(conf.AuditLogDir)
+	reserved := multusAdmissionControllerReservedMountPaths
+	if conf.TokenMountPath != "" {
+		reserved = append([]string{path.Clean(conf.TokenMountPath)}, reserved...)
+	}
+	for _, mountPath := range reserved {
+		if dir == mountPath || strings.HasPrefix(dir, mountPath+"/") || strings.HasPrefix(mountPath, dir+"/") {
+			return fmt.Errorf("invalid audit-log-dir %q: overlaps the %s mount", conf.AuditLogDir, mountPath)
+		}
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerConfig checks the multus admission controller overrides
+func validateMultusAdmissionControllerConfig(conf *bootstrap.MultusAdmissionControllerBootstrapResult) error {
+	if conf.TokenMountPath != "" && !path.IsAbs(conf.TokenMountPath) {
+		return fmt.Errorf("invalid token-mount-path %q: must be an absolute path", conf.TokenMountPath)
+	}
+	if _, err := normalizeImageReference(conf.KubeRBACProxyFallbackImage); err != nil {
+		return fmt.Errorf("invalid kube-rbac-proxy-fallback-image: %w", err)
+	}
+	if conf.AuditLogDir != "" {
+		if err := validateMultusAdmissionControllerAuditLogDir(conf); err != nil {
+			return err
+		}
+	}
+	if conf.AuditLogHostPath != "" {
+		if conf.AuditLogDir == "" {
+			return fmt.Errorf("audit-log-host-path requires audit-log-dir")
+		}
+		if !path.IsAbs(conf.AuditLogHostPath) || path.Clean(conf.AuditLogHostPath) == "/" {
+			return fmt.Errorf("invalid audit-log-host-path %q: must be an absolute path other than /", conf.AuditLogHostPath)
+		}
+	}
+	if conf.LogLevel != nil && (*conf.LogLevel < 0 || *conf.LogLevel > 10) {
+		return fmt.Errorf("invalid log-level %d: must be between 0 and 10", *conf.LogLevel)
+	}
+	if conf.WebhookWorkerCount != nil && (*conf.WebhookWorkerCount < 1 || *conf.WebhookWorkerCount > maxMultusAdmissionControllerWebhookWorkers) {
+		return fmt.Errorf("invalid webhook-worker-count %d: must be between 1 and %d", *conf.WebhookWorkerCount,
+			maxMultusAdmissionControllerWebhookWorkers)
+	}
+	switch conf.MetricsAuth {
+	case "", multusAdmissionControllerMetricsAuthKubeRBACProxy, multusAdmissionControllerMetricsAuthNone:
+	default:
+		return fmt.Errorf("invalid metrics-auth %q: must be one of %q, %q", conf.MetricsAuth,
+			multusAdmissionControllerMetricsAuthKubeRBACProxy, multusAdmissionControllerMetricsAuthNone)
+	}
+	switch conf.NamespacePolicy {
+	case "", multusAdmissionControllerNamespaceRequireExists, multusAdmissionControllerNamespaceRender:
+	default:
+		return fmt.Errorf("invalid namespace-policy %q: must be one of %q, %q", conf.NamespacePolicy,
+			multusAdmissionControllerNamespaceRequireExists, multusAdmissionControllerNamespaceRender)
+	}
+	switch conf.SessionAffinity {
+	case "", corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP:
+	default:
+		return fmt.Errorf("invalid session-affinity %q: must be one of %q, %q", conf.SessionAffinity,
+			corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP)
+	}
+	if conf.SessionAffinityTimeout != nil {
+		if conf.SessionAffinity != corev1.ServiceAffinityClientIP {
+			return fmt.Errorf("session-affinity-timeout requires session-affinity %q", corev1.ServiceAffinityClientIP)
+		}
+		if *conf.SessionAffinityTimeout <= 0 || *conf.SessionAffinityTimeout > maxMultusAdmissionControllerSessionAffinitySeconds {
+			return fmt.Errorf("invalid session-affinity-timeout %d: must be between 1 and %d", *conf.SessionAffinityTimeout,
+				maxMultusAdmissionControllerSessionAffinitySeconds)
+		}
+	}
+	if conf.TopologyMode != "" && conf.TopologyMode != multusAdmissionControllerTopologyModeAuto {
+		return fmt.Errorf("invalid topology-mode %q: must be %q", conf.TopologyMode, multusAdmissionControllerTopologyModeAuto)
+	}
+	switch conf.InternalTrafficPolicy {
+	case "", corev1.ServiceInternalTrafficPolicyCluster, corev1.ServiceInternalTrafficPolicyLocal:
+	default:
+		return fmt.Errorf("invalid internal-traffic-policy %q: must be one of %q, %q", conf.InternalTrafficPolicy,
+			corev1.ServiceInternalTrafficPolicyCluster, corev1.ServiceInternalTrafficPolicyLocal)
+	}
+	switch conf.DNSPolicy {
+	case "", corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSNone:
+		if len(conf.DNSNameservers) == 0 {
+			return fmt.Errorf("dns-policy %q requires dns-nameservers", corev1.DNSNone)
+		}
+	default:
+		return fmt.Errorf("invalid dns-policy %q: must be one of %q, %q, %q, %q", conf.DNSPolicy,
+			corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault, corev1.DNSNone)
+	}
+	if len(conf.DNSNameservers) > maxMultusAdmissionControllerDNSNameservers {
+		return fmt.Errorf("invalid dns-nameservers: at most %d nameservers are allowed", maxMultusAdmissionControllerDNSNameservers)
+	}
+	for _, nameserver := range conf.DNSNameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("invalid dns-nameservers entry %q: must be an IP address", nameserver)
+		}
+	}
+	for _, revision := range []struct{ key, value string }{
+		{"revision", conf.Revision},
+		{"previous-revision", conf.PreviousRevision},
+	} {
+		if revision.value == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(revision.value); len(errs) > 0 {
+			return fmt.Errorf("invalid %s %q: %s", revision.key, revision.value, strings.Join(errs, ", "))
+		}
+	}
+	for _, version := range conf.AdmissionReviewVersions {
+		if version != multusAdmissionControllerAdmissionReviewV1 && version != multusAdmissionControllerAdmissionReviewV1beta1 {
+			return fmt.Errorf("invalid admission-review-versions %q: must be %q or %q", version,
+				multusAdmissionControllerAdmissionReviewV1, multusAdmissionControllerAdmissionReviewV1beta1)
+		}
+	}
+	for i, rule := range conf.ExtraWebhookRules {
+		if err := validateMultusAdmissionControllerWebhookRule(rule); err != nil {
+			return fmt.Errorf("invalid extra-webhook-rules rule %d: %w", i, err)
+		}
+	}
+	if _, err := metav1.ParseToLabelSelector(conf.WebhookObjectSelector); err != nil {
+		return fmt.Errorf("invalid webhook-object-selector %q: %w", conf.WebhookObjectSelector, err)
+	}
+	for _, alias := range conf.HostAliases {
+		if net.ParseIP(alias.IP) == nil {
+			return fmt.Errorf("invalid host-aliases IP %q: must be an IPv4 or IPv6 address", alias.IP)
+		}
+		if len(alias.Hostnames) == 0 {
+			return fmt.Errorf("invalid host-aliases entry for %s: no hostnames", alias.IP)
+		}
+		for _, hostname := range alias.Hostnames {
+			if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+				return fmt.Errorf("invalid host-aliases hostname %q: %s", hostname, strings.Join(errs, ", "))
+			}
+		}
+	}
+	extraEnv := sets.New[string]()
+	for _, env := range conf.ExtraEnv {
+		if errs := validation.IsEnvVarName(env.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid extra-env name %q: %s", env.Name, strings.Join(errs, ", "))
+		}
+		if multusAdmissionControllerReservedEnvVars.Has(env.Name) {
+			return fmt.Errorf("invalid extra-env name %q: reserved for the variables the render sets", env.Name)
+		}
+		if extraEnv.Has(env.Name) {
+			return fmt.Errorf("invalid extra-env name %q: set more than once", env.Name)
+		}
+		extraEnv.Insert(env.Name)
+	}
+	for i, selected := range conf.BackupAnnotations {
+		if selected.Kind == "" {
+			return fmt.Errorf("invalid backup-annotations entry %d: no kind", i)
+		}
+		for key := range selected.Annotations {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("invalid backup-annotations annotation %q: %s", key, strings.Join(errs, ", "))
+			}
+			for _, domain := range multusAdmissionControllerOperatorAnnotationDomains {
+				if strings.HasPrefix(key, domain+"/") {
+					return fmt.Errorf("invalid backup-annotations annotation %q: %s annotations are managed by the operator", key, domain)
+				}
+			}
+		}
+	}
+	for image, digest := range conf.ImageDigests {
+		if !multusAdmissionControllerImageDigestRegexp.MatchString(digest) {
+			return fmt.Errorf("invalid image-digests digest %q of %s: must be sha256:<64 hex characters>", digest, image)
+		}
+	}
+	if conf.IgnoredNamespacesRefreshInterval != nil && *conf.IgnoredNamespacesRefreshInterval <= 0 {
+		return fmt.Errorf("invalid ignored-namespaces-refresh-interval %s: must be positive", *conf.IgnoredNamespacesRefreshInterval)
+	}
+	if conf.IgnoredNamespacesGracePeriod != nil && *conf.IgnoredNamespacesGracePeriod < 0 {
+		return fmt.Errorf("invalid ignored-namespaces-grace-period %s: must not be negative", *conf.IgnoredNamespacesGracePeriod)
+	}
+	if conf.ReconcileInterval != nil &&
+		(*conf.ReconcileInterval < multusAdmissionControllerMinReconcileInterval || *conf.ReconcileInterval > multusAdmissionControllerMaxReconcileInterval) {
+		return fmt.Errorf("invalid reconcile-interval %s: must be between %s and %s",
+			*conf.ReconcileInterval, multusAdmissionControllerMinReconcileInterval, multusAdmissionControllerMaxReconcileInterval)
+	}
+	if conf.TokenMinterRequestTimeout != nil &&
+		(*conf.TokenMinterRequestTimeout <= 0 || *conf.TokenMinterRequestTimeout > multusAdmissionControllerMaxTokenMinterRequestTimeout) {
+		return fmt.Errorf("invalid token-minter-request-timeout %s: must be positive and at most %s",
+			*conf.TokenMinterRequestTimeout, multusAdmissionControllerMaxTokenMinterRequestTimeout)
+	}
+	if conf.FSGroup != nil && (*conf.FSGroup < 0 || *conf.FSGroup > math.MaxInt32) {
+		return fmt.Errorf("invalid fs-group %d: must be between 0 and %d", *conf.FSGroup, math.MaxInt32)
+	}
+	for _, gid := range conf.SupplementalGroups {
+		if gid < 0 || gid > math.MaxInt32 {
+			return fmt.Errorf("invalid supplemental-groups %d: must be between 0 and %d", gid, math.MaxInt32)
+		}
+	}
+	if conf.RevisionHistoryLimit != nil && *conf.RevisionHistoryLimit < 0 {
+		return fmt.Errorf("invalid revision-history-limit %d: must not be negative", *conf.RevisionHistoryLimit)
+	}
+	if conf.MetricsPort != nil {
+		if *conf.MetricsPort <= 0 || *conf.MetricsPort > 65535 {
+			return fmt.Errorf("invalid metrics-port %d: must be between 1 and 65535", *conf.MetricsPort)
+		}
+	}
+	if err := validateMultusAdmissionControllerPorts(multusAdmissionControllerWebhookPort, multusAdmissionControllerMetricsListenPort,
+		valueOrDefault(conf.MetricsPort, defaultMultusAdmissionControllerMetricsPort)); err != nil {
+		return err
+	}
+	if conf.MetricsPath != "" && !path.IsAbs(conf.MetricsPath) {
+		return fmt.Errorf("invalid metrics-path %q: must be an absolute path", conf.MetricsPath)
+	}
+	if err := validateMultusAdmissionControllerProbes(conf); err != nil {
+		return err
+	}
+	if err := validateMultusAdmissionControllerAutoscaling(conf); err != nil {
+		return err
+	}
+	ephemeralStorageRequest, ephemeralStorageLimit := multusAdmissionControllerEphemeralStorage(conf)
+	if ephemeralStorageRequest.Sign() < 0 {
+		return fmt.Errorf("invalid ephemeral-storage-request %s: must not be negative", ephemeralStorageRequest.String())
+	}
+	if ephemeralStorageLimit.Cmp(ephemeralStorageRequest) < 0 {
+		return fmt.Errorf("invalid ephemeral-storage-limit %s: must not be less than ephemeral-storage-request %s",
+			ephemeralStorageLimit.String(), ephemeralStorageRequest.String())
+	}
+	if conf.ScratchVolumeSizeLimit != nil {
+		if conf.ScratchVolumeSizeLimit.Sign() <= 0 {
+			return fmt.Errorf("invalid scratch-volume-size-limit %s: must be positive", conf.ScratchVolumeSizeLimit.String())
+		}
+		// the scratch volumes count against the pod ephemeral storage
+		if conf.ScratchVolumeSizeLimit.Cmp(ephemeralStorageLimit) > 0 {
+			return fmt.Errorf("invalid scratch-volume-size-limit %s: must not be greater than ephemeral-storage-limit %s",
+				conf.ScratchVolumeSizeLimit.String(), ephemeralStorageLimit.String())
+		}
+	}
+	if conf.PreStopSleepSeconds != nil && (*conf.PreStopSleepSeconds < 0 || *conf.PreStopSleepSeconds >= corev1.DefaultTerminationGracePeriodSeconds) {
+		return fmt.Errorf("invalid pre-stop-sleep-seconds %d: must be between 0 and %d, less than the termination grace period",
+			*conf.PreStopSleepSeconds, corev1.DefaultTerminationGracePeriodSeconds-1)
+	}
+	// the reviews in flight finish draining before the pod is killed at the end of the grace period
+	if conf.ShutdownTimeout != nil {
+		preStopSleep := time.Duration(valueOrDefault(conf.PreStopSleepSeconds, defaultMultusAdmissionControllerPreStopSleepSeconds)) * time.Second
+		gracePeriod := time.Duration(corev1.DefaultTerminationGracePeriodSeconds) * time.Second
+		if *conf.ShutdownTimeout <= 0 {
+			return fmt.Errorf("invalid shutdown-timeout %s: must be positive", *conf.ShutdownTimeout)
+		}
+		if preStopSleep+*conf.ShutdownTimeout > gracePeriod {
+			return fmt.Errorf("invalid shutdown-timeout %s: with the %s preStop sleep, must not exceed the %s termination grace period",
+				*conf.ShutdownTimeout, preStopSleep, gracePeriod)
+		}
+	}
+	// with the probes on, a rollout always makes progress by the end of the startup budget, or the
+	// pods are restarted
+	progressDeadline := valueOrDefault(conf.ProgressDeadlineSeconds, defaultMultusAdmissionControllerProgressDeadlineSeconds)
+	if conf.HealthProbes {
+		if startupBudget := multusAdmissionControllerStartupBudgetSeconds(conf); progressDeadline <= startupBudget {
+			return fmt.Errorf("invalid progress-deadline-seconds %d: must be greater than the %d seconds startup probe budget", progressDeadline, startupBudget)
+		}
+	}
+	switch conf.SeccompProfileType {
+	case "", corev1.SeccompProfileTypeRuntimeDefault:
+		if conf.SeccompLocalhostProfile != "" {
+			return fmt.Errorf("seccomp-localhost-profile requires seccomp-profile-type %q", corev1.SeccompProfileTypeLocalhost)
+		}
+	case corev1.SeccompProfileTypeLocalhost:
+		// the kubelet resolves the profile relative to its seccomp profile root
+		if conf.SeccompLocalhostProfile == "" {
+			return fmt.Errorf("seccomp-profile-type %q requires seccomp-localhost-profile", corev1.SeccompProfileTypeLocalhost)
+		}
+		if path.IsAbs(conf.SeccompLocalhostProfile) || strings.Contains(conf.SeccompLocalhostProfile, "..") {
+			return fmt.Errorf("invalid seccomp-localhost-profile %q: must be a path relative to the kubelet seccomp profile root, without '..'",
+				conf.SeccompLocalhostProfile)
+		}
+	default:
+		return fmt.Errorf("invalid seccomp-profile-type %q: must be one of %q, %q", conf.SeccompProfileType,
+			corev1.SeccompProfileTypeRuntimeDefault, corev1.SeccompProfileTypeLocalhost)
+	}
+	if len(conf.CommandOverride) > 0 && !conf.DevelopmentMode {
+		return fmt.Errorf("command-override is only allowed with development-mode")
+	}
+	switch conf.NamespaceSelectorStrategy {
+	case "", multusAdmissionControllerNamespaceSelectorNames, multusAdmissionControllerNamespaceSelectorLabels:
+	default:
+		return fmt.Errorf("invalid namespace-selector-strategy %q: must be one of %q, %q", conf.NamespaceSelectorStrategy,
+			multusAdmissionControllerNamespaceSelectorNames, multusAdmissionControllerNamespaceSelectorLabels)
+	}
+	switch conf.WebhookRegistration {
+	case "", multusAdmissionControllerWebhookRegistrationImmediate, multusAdmissionControllerWebhookRegistrationAfterReady:
+	default:
+		return fmt.Errorf("invalid webhook-registration %q: must be one of %q, %q", conf.WebhookRegistration,
+			multusAdmissionControllerWebhookRegistrationImmediate, multusAdmissionControllerWebhookRegistrationAfterReady)
+	}
+	switch conf.RunAsUserSource {
+	case "", multusAdmissionControllerRunAsUserStatic, multusAdmissionControllerRunAsUserNamespaceRange:
+	default:
+		return fmt.Errorf("invalid run-as-user-source %q: must be one of %q, %q", conf.RunAsUserSource,
+			multusAdmissionControllerRunAsUserStatic, multusAdmissionControllerRunAsUserNamespaceRange)
+	}
+	switch conf.WebhookMatchPolicy {
+	case "", admissionregistrationv1.Equivalent, admissionregistrationv1.Exact:
+	default:
+		return fmt.Errorf("invalid webhook-match-policy %q: must be one of %q, %q", conf.WebhookMatchPolicy,
+			admissionregistrationv1.Equivalent, admissionregistrationv1.Exact)
+	}
+	switch conf.WebhookUpdateStrategy {
+	case "", multusAdmissionControllerWebhookUpdateApply, multusAdmissionControllerWebhookUpdateReplaceOnRulesChange:
+	default:
+		return fmt.Errorf("invalid webhook-update-strategy %q: must be one of %q, %q", conf.WebhookUpdateStrategy,
+			multusAdmissionControllerWebhookUpdateApply, multusAdmissionControllerWebhookUpdateReplaceOnRulesChange)
+	}
+	switch conf.OutputFormat {
+	case "", multusAdmissionControllerOutputFormatRaw, multusAdmissionControllerOutputFormatTemplate:
+	default:
+		return fmt.Errorf("invalid output-format %q: must be one of %q, %q", conf.OutputFormat,
+			multusAdmissionControllerOutputFormatRaw, multusAdmissionControllerOutputFormatTemplate)
+	}
+	switch conf.CertReloadStrategy {
+	case "", multusAdmissionControllerCertReloadPodRestart, multusAdmissionControllerCertReloadFileWatch:
+	default:
+		return fmt.Errorf("invalid cert-reload-strategy %q: must be one of %q, %q", conf.CertReloadStrategy,
+			multusAdmissionControllerCertReloadPodRestart, multusAdmissionControllerCertReloadFileWatch)
+	}
+	switch conf.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return fmt.Errorf("invalid image-pull-policy %q: must be one of %q, %q, %q", conf.ImagePullPolicy,
+			corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever)
+	}
+	// admissionregistration.k8s.io/v1 only allows the dry-run safe classes
+	switch conf.SideEffects {
+	case "", admissionregistrationv1.SideEffectClassNone, admissionregistrationv1.SideEffectClassNoneOnDryRun:
+	default:
+		return fmt.Errorf("invalid side-effects %q: must be one of %q, %q", conf.SideEffects,
+			admissionregistrationv1.SideEffectClassNone, admissionregistrationv1.SideEffectClassNoneOnDryRun)
+	}
+	for key, policy := range map[string]admissionregistrationv1.FailurePolicyType{
+		"failure-policy":        conf.FailurePolicy,
+		"paused-failure-policy": conf.PausedFailurePolicy,
+	} {
+		switch policy {
+		case "", admissionregistrationv1.Ignore, admissionregistrationv1.Fail:
+		default:
+			return fmt.Errorf("invalid %s %q: must be one of %q, %q", key, policy,
+				admissionregistrationv1.Ignore, admissionregistrationv1.Fail)
+		}
+	}
+	if conf.ServiceAccountName != "" {
+		if errs := validation.IsDNS1123Subdomain(conf.ServiceAccountName); len(errs) > 0 {
+			return fmt.Errorf("invalid service-account-name %q: %s", conf.ServiceAccountName, strings.Join(errs, ", "))
+		}
+	}
+	if conf.RuntimeClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(conf.RuntimeClassName); len(errs) > 0 {
+			return fmt.Errorf("invalid runtime-class-name %q: %s", conf.RuntimeClassName, strings.Join(errs, ", "))
+		}
+	}
+	if conf.ServiceCAKey != "" {
+		if errs := validation.IsConfigMapKey(conf.ServiceCAKey); len(errs) > 0 {
+			return fmt.Errorf("invalid service-ca-key %q: %s", conf.ServiceCAKey, strings.Join(errs, ", "))
+		}
+	}
+	if conf.ServingCertSecret != "" {
+		if errs := validation.IsDNS1123Subdomain(conf.ServingCertSecret); len(errs) > 0 {
+			return fmt.Errorf("invalid serving-cert-secret %q: %s", conf.ServingCertSecret, strings.Join(errs, ", "))
+		}
+	}
+	maxUnavailable, err := validateMultusAdmissionControllerRollingUpdate("max-unavailable", conf.MaxUnavailable)
+	if err != nil {
+		return err
+	}
+	maxSurge, err := validateMultusAdmissionControllerRollingUpdate("max-surge", conf.MaxSurge)
+	if err != nil {
+		return err
+	}
+	if conf.MaxUnavailable != nil && conf.MaxSurge != nil && maxUnavailable == 0 && maxSurge == 0 {
+		return fmt.Errorf("invalid max-unavailable and max-surge: both may not be 0")
+	}
+	if conf.PreviousRevision != "" {
+		if conf.Revision == "" {
+			return fmt.Errorf("previous-revision requires revision")
+		}
+		if conf.PreviousRevision == conf.Revision {
+			return fmt.Errorf("invalid previous-revision %q: must differ from revision", conf.PreviousRevision)
+		}
+	}
+	return nil
+}
+
+// validateMultusAdmissionControllerWebhookRule checks that an extra webhook rule names explicit
+// resources, so that they can be checked against discovery
+func validateMultusAdmissionControllerWebhookRule(rule admissionregistrationv1.RuleWithOperations) error {
+	if len(rule.Operations) == 0 {
+		return fmt.Errorf("operations must not be empty")
+	}
+	for _, operation := range rule.Operations {
+		switch operation {
+		case admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete,
+			admissionregistrationv1.Connect, admissionregistrationv1.OperationAll:
+		default:
+			return fmt.Errorf("unsupported operation %q", operation)
+		}
+	}
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{"apiGroups", rule.APIGroups},
+		{"apiVersions", rule.APIVersions},
+		{"resources", rule.Resources},
+	} {
+		if len(field.values) == 0 {
+			return fmt.Errorf("%s must not be empty", field.name)
+		}
+		for _, value := range field.values {
+			if strings.Contains(value, "*") {
+				return fmt.Errorf("%s %q must not be a wildcard", field.name, value)
+			}
+			// the core group is the only empty value allowed
+			if value == "" && field.name != "apiGroups" {
+				return fmt.Errorf("%s must not contain an empty value", field.name)
+			}
+		}
+	}
+	return nil
+}
@@ -1,15 +1,75 @@
 package network
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	openapi_v2 "github.com/google/gnostic/openapiv2"
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
 	operv1 "github.com/openshift/api/operator/v1"
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	cnoclient "github.com/openshift/cluster-network-operator/pkg/client"
 	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+	hyperv1 "github.com/openshift/hypershift/api/v1beta1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	cnofake "github.com/openshift/cluster-network-operator/pkg/client/fake"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/component-base/metrics/testutil"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var MultusAdmissionControllerConfig = operv1.Network{
@@ -30,6 +90,120 @@ var MultusAdmissionControllerConfig = operv1.Network{
 	},
 }
 
+// fakeMultusAdmissionControllerHyperShift returns a bootstrap result and a client for rendering
+// multus admission controller in HyperShift
+func fakeMultusAdmissionControllerHyperShift() (*bootstrap.BootstrapResult, cnoclient.Client) {
+	return fakeMultusAdmissionControllerHyperShiftWithCA("test-ca")
+}
+
+// fakeMultusAdmissionControllerHyperShiftWithCA is fakeMultusAdmissionControllerHyperShift with the
+// given management cluster service CA
+func fakeMultusAdmissionControllerHyperShiftWithCA(ca string) (*bootstrap.BootstrapResult, cnoclient.Client) {
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.Infra.ControlPlaneTopology = configv1.ExternalTopologyMode
+	bootstrapResult.Infra.APIServers[bootstrap.APIServerDefaultLocal] = bootstrap.APIServer{
+		Host: "testing.local",
+		Port: "6443",
+	}
+	bootstrapResult.Infra.HostedControlPlane = &hyperv1.HostedControlPlane{
+		Spec: hyperv1.HostedControlPlaneSpec{
+			ClusterID: "test-cluster-id",
+		},
+	}
+	bootstrapResult.MultusAdmissionController.HyperShiftConfig = &bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult{
+		Enabled:      true,
+		Namespace:    "clusters-test",
+		ReleaseImage: "quay.io/openshift/release:test",
+	}
+
+	client := cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "clusters-test",
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "openshift-service-ca.crt",
+					Namespace: "clusters-test",
+				},
+				Data: map[string]string{
+					"service-ca.crt": ca,
+				},
+			},
+		},
+	})
+	return bootstrapResult, client
+}
+
+// multusAdmissionControllerTestConfig returns a defaulted network config with multus enabled
+func multusAdmissionControllerTestConfig() *operv1.NetworkSpec {
+	crd := MultusAdmissionControllerConfig.DeepCopy()
+	config := &crd.Spec
+	fillDefaults(config, nil)
+	return config
+}
+
+// getMultusAdmissionControllerDeployment returns the rendered multus admission controller Deployment
+func getMultusAdmissionControllerDeployment(g *WithT, objs []*uns.Unstructured) *appsv1.Deployment {
+	for _, obj := range objs {
+		if obj.GetKind() == "Deployment" && obj.GetName() == "multus-admission-controller" {
+			deployment := &appsv1.Deployment{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment)).To(Succeed())
+			return deployment
+		}
+	}
+	g.Expect(false).To(BeTrue(), "multus-admission-controller Deployment not rendered")
+	return nil
+}
+
+// generateTestCA returns a PEM encoded self-signed CA certificate, issued at notBefore
+func generateTestCA(g *WithT, commonName string, notBefore time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// getMultusAdmissionControllerService returns the rendered multus admission controller Service
+func getMultusAdmissionControllerService(g *WithT, objs []*uns.Unstructured) *corev1.Service {
+	for _, obj := range objs {
+		if obj.GetKind() == "Service" && obj.GetName() == "multus-admission-controller" {
+			service := &corev1.Service{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, service)).To(Succeed())
+			return service
+		}
+	}
+	g.Expect(false).To(BeTrue(), "multus-admission-controller Service not rendered")
+	return nil
+}
+
+// getContainer returns the named container of the pod spec
+func getContainer(g *WithT, spec *corev1.PodSpec, name string) *corev1.Container {
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == name {
+			return &spec.InitContainers[i]
+		}
+	}
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i]
+		}
+	}
+	g.Expect(false).To(BeTrue(), "container %s not rendered", name)
+	return nil
+}
+
 // TestRenderMultusAdmissionController has some simple rendering tests
 func TestRenderMultusAdmissionController(t *testing.T) {
 	g := NewGomegaWithT(t)
@@ -64,14 +238,14 @@ func TestRenderMultusAdmissionController(t *testing.T) {
 	bootstrap := fakeBootstrapResult()
 
 	// disable MultusAdmissionController
-	objs, err := renderMultusAdmissionController(config, manifestDir, false, bootstrap, fakeClient)
+	objs, err := renderMultusAdmissionController(config, manifestDir, false, bootstrap, fakeClient, featuregates.NewFeatureGate(nil, nil))
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
 
 	// enable MultusAdmissionController
 	enabled := false
 	config.DisableMultiNetwork = &enabled
-	objs, err = renderMultusAdmissionController(config, manifestDir, false, bootstrap, fakeClient)
+	objs, err = renderMultusAdmissionController(config, manifestDir, false, bootstrap, fakeClient, featuregates.NewFeatureGate(nil, nil))
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
 
@@ -113,3 +287,4140 @@ func TestRenderMultusAdmissionControllerGetNamespace(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(namespaces).To(Equal("test1-ignored,test3-ignored"))
 }
+
+// TestRenderMultusAdmissionControllerGetOptOutNamespaces tests that getOpenshiftNamespaces() merges
+// the namespaces that opted out with a label
+func TestRenderMultusAdmissionControllerGetOptOutNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(validation.IsQualifiedName(multusAdmissionControllerOptOutLabel)).To(BeEmpty())
+	g.Expect(validation.IsValidLabelValue(multusAdmissionControllerOptOutValue)).To(BeEmpty())
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	fakeClient := cnofake.NewFakeClient(
+		namespace("openshift-monitored", map[string]string{"openshift.io/cluster-monitoring": "true"}),
+		namespace("openshift-monitored-opted-out", map[string]string{
+			"openshift.io/cluster-monitoring": "true",
+			"multus.openshift.io/admission":   "ignore",
+		}),
+		namespace("team-opted-out", map[string]string{"multus.openshift.io/admission": "ignore"}),
+		namespace("team-invalid-value", map[string]string{"multus.openshift.io/admission": "true"}),
+		namespace("team-validated", nil),
+	)
+	namespaces, err := getOpenshiftNamespaces(fakeClient)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(namespaces).To(Equal("openshift-monitored,openshift-monitored-opted-out,team-opted-out"))
+}
+
+// TestRenderMultusAdmissionControllerIgnoredNamespacesMetric tests that the render reports the number of ignored namespaces
+func TestRenderMultusAdmissionControllerIgnoredNamespacesMetric(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{}
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+
+	crd := MultusAdmissionControllerConfig.DeepCopy()
+	config := &crd.Spec
+	fillDefaults(config, nil)
+
+	fakeClient := cnofake.NewFakeClient(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test1-ignored",
+				Labels: map[string]string{
+					"openshift.io/cluster-monitoring": "true",
+				},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test2-not-ignored",
+			},
+		})
+
+	_, err := renderMultusAdmissionController(config, manifestDir, false, fakeBootstrapResult(), fakeClient, featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	value, err := testutil.GetGaugeMetricValue(multusAdmissionControllerIgnoredNamespaces)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal(float64(len(staticIgnoredNamespaces) + 1)))
+
+	// a failed render doesn't report its ignored namespaces
+	multusAdmissionControllerIgnoredNamespaces.Set(-1)
+	_, err = renderMultusAdmissionController(config, "/nonexistent", false, fakeBootstrapResult(), fakeClient, featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).To(HaveOccurred())
+	value, err = testutil.GetGaugeMetricValue(multusAdmissionControllerIgnoredNamespaces)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal(float64(-1)))
+}
+
+// TestRenderMultusAdmissionControllerTokenMountPath tests that all HyperShift containers share the token path
+func TestRenderMultusAdmissionControllerTokenMountPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	for _, tokenMountPath := range []string{"", "/var/run/secrets/custom/"} {
+		expected := "/var/run/secrets/hosted_cluster"
+		if tokenMountPath != "" {
+			expected = "/var/run/secrets/custom"
+		}
+		bootstrapResult.MultusAdmissionController.TokenMountPath = tokenMountPath
+
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		deployment := getMultusAdmissionControllerDeployment(g, objs)
+
+		minter := getContainer(g, &deployment.Spec.Template.Spec, "hosted-cluster-token")
+		g.Expect(minter.Args).To(ContainElement("--token-file=" + expected + "/token"))
+		g.Expect(minter.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "hosted-cluster-api-access", MountPath: expected}))
+
+		controller := getContainer(g, &deployment.Spec.Template.Spec, "multus-admission-controller")
+		g.Expect(controller.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "hosted-cluster-api-access", MountPath: expected}))
+		g.Expect(controller.Command[2]).To(ContainSubstring(expected + "/token"))
+		g.Expect(controller.Env).To(ContainElement(corev1.EnvVar{Name: "KUBECONFIG", Value: expected + "/kubeconfig"}))
+
+		setup := getContainer(g, &deployment.Spec.Template.Spec, "hosted-cluster-kubecfg-setup")
+		g.Expect(setup.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "hosted-cluster-api-access", MountPath: expected}))
+	}
+
+	bootstrapResult.MultusAdmissionController.TokenMountPath = "relative/path"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("must be an absolute path")))
+}
+
+// TestBootstrapMultusAdmissionController tests reading the multus-admission-controller-config ConfigMap
+func TestBootstrapMultusAdmissionController(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := bootstrapMultusAdmissionController(cnofake.NewFakeClient())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.TokenMountPath).To(BeEmpty())
+
+	client := cnofake.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.MULTUS_ADMISSION_CONTROLLER_CONFIG,
+			Namespace: names.APPLIED_NAMESPACE,
+		},
+		Data: map[string]string{
+			"token-mount-path": "/var/run/secrets/custom",
+			"ipam-hints":       "true",
+			"log-level":        "4",
+		},
+	})
+	result, err = bootstrapMultusAdmissionController(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.TokenMountPath).To(Equal("/var/run/secrets/custom"))
+	g.Expect(result.IPAMHints).To(BeTrue())
+	g.Expect(result.LogLevel).To(HaveValue(Equal(4)))
+}
+
+// TestRenderMultusAdmissionControllerReleaseVersion tests that env changes between renders are picked up
+func TestRenderMultusAdmissionControllerReleaseVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, version := range []string{"4.15.0", "4.15.1"} {
+		t.Setenv("RELEASE_VERSION", version)
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		deployment := getMultusAdmissionControllerDeployment(g, objs)
+		g.Expect(deployment.Annotations).To(HaveKeyWithValue("release.openshift.io/version", version))
+	}
+}
+
+// TestRenderMultusAdmissionControllerIPAMHints tests passing the cluster IPAM context to the admission controller
+func TestRenderMultusAdmissionControllerIPAMHints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := multusAdmissionControllerTestConfig()
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(config, manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	controller := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(controller.Env).To(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.IPAMHints = true
+	objs, err = renderMultusAdmissonControllerConfig(config, manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	controller = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(controller.Env).To(ConsistOf(
+		corev1.EnvVar{Name: "SERVICE_NETWORK_CIDRS", Value: "172.30.0.0/16"},
+		corev1.EnvVar{Name: "CLUSTER_NETWORK_CIDRS", Value: "10.128.0.0/15"},
+		corev1.EnvVar{Name: "WHEREABOUTS_ENABLED", Value: "false"},
+	))
+}
+
+// TestVerifyMultusAdmissionControllerCA tests the TLS handshake against the webhook with the rendered caBundle
+func TestVerifyMultusAdmissionControllerCA(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	webhook := func(serverURL string, ca []byte) []*uns.Unstructured {
+		return []*uns.Unstructured{{
+			Object: map[string]interface{}{
+				"apiVersion": "admissionregistration.k8s.io/v1",
+				"kind":       "ValidatingWebhookConfiguration",
+				"metadata": map[string]interface{}{
+					"name": names.MULTUS_VALIDATING_WEBHOOK,
+				},
+				"webhooks": []interface{}{
+					map[string]interface{}{
+						"name": "multus-validating-config.k8s.io",
+						"clientConfig": map[string]interface{}{
+							"url":      serverURL + "/validate",
+							"caBundle": base64.URLEncoding.EncodeToString(ca),
+						},
+					},
+				},
+			},
+		}}
+	}
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	otherCA := generateTestCA(g, "other-ca", time.Now())
+
+	g.Expect(VerifyMultusAdmissionControllerCA(context.TODO(), webhook(server.URL, serverCA))).To(Succeed())
+
+	err := VerifyMultusAdmissionControllerCA(context.TODO(), webhook(server.URL, otherCA))
+	var certErr *tls.CertificateVerificationError
+	g.Expect(errors.As(err, &certErr)).To(BeTrue())
+
+	// a webhook that never completes the handshake is given up on at the ctx deadline
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer listener.Close()
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	err = VerifyMultusAdmissionControllerCA(ctx, webhook("https://"+listener.Addr().String(), serverCA))
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+	g.Expect(errors.As(err, &certErr)).To(BeFalse())
+
+	// an unreachable webhook is not a CA problem
+	server.Close()
+	err = VerifyMultusAdmissionControllerCA(context.TODO(), webhook(server.URL, serverCA))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.As(err, &certErr)).To(BeFalse())
+
+	// the webhook service is not checked when the CA bundle is injected
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(VerifyMultusAdmissionControllerCA(context.TODO(), objs)).To(Succeed())
+}
+
+// TestRecoverMultusAdmissionControllerServingSecret tests that a deleted serving Secret is reported,
+// and that the Service is reset for the service CA operator to generate it again
+func TestRecoverMultusAdmissionControllerServingSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	now := time.Now()
+	service := func(created time.Time) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:              "multus-admission-controller",
+			Namespace:         "openshift-multus",
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations: map[string]string{
+				"service.alpha.openshift.io/serving-cert-secret-name":         "multus-admission-controller-secret",
+				"service.beta.openshift.io/serving-cert-generation-error":     "failed to generate the certificate",
+				"service.beta.openshift.io/serving-cert-generation-error-num": "10",
+			},
+		}}
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "multus-admission-controller-secret", Namespace: "openshift-multus"}}
+
+	client := cnofake.NewFakeClient(service(now.Add(-time.Hour)), secret)
+	g.Expect(RecoverMultusAdmissionControllerServingSecret(context.TODO(), client, objs, now)).To(Succeed())
+
+	client = cnofake.NewFakeClient(service(now.Add(-time.Hour)))
+	err = RecoverMultusAdmissionControllerServingSecret(context.TODO(), client, objs, now)
+	var missingErr *MultusAdmissionControllerServingSecretMissingError
+	g.Expect(errors.As(err, &missingErr)).To(BeTrue())
+	g.Expect(missingErr).To(Equal(&MultusAdmissionControllerServingSecretMissingError{
+		Namespace: "openshift-multus",
+		Name:      "multus-admission-controller-secret",
+	}))
+	recovered, err := client.Default().Kubernetes().CoreV1().Services("openshift-multus").Get(context.TODO(), "multus-admission-controller", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recovered.Annotations).To(Equal(map[string]string{
+		"service.alpha.openshift.io/serving-cert-secret-name": "multus-admission-controller-secret",
+	}))
+
+	// the service CA operator is given time to generate the Secret of a new Service
+	client = cnofake.NewFakeClient(service(now.Add(-time.Minute)))
+	g.Expect(RecoverMultusAdmissionControllerServingSecret(context.TODO(), client, objs, now)).To(Succeed())
+}
+
+// TestRenderMultusAdmissionControllerServiceCAHash tests that a CA change changes the pod template
+func TestRenderMultusAdmissionControllerServiceCAHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	caHash := func(ca string) string {
+		bootstrapResult, client := fakeMultusAdmissionControllerHyperShiftWithCA(ca)
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		annotations := getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Annotations
+		g.Expect(annotations).To(HaveKey("network.operator.openshift.io/service-ca-hash"))
+		return annotations["network.operator.openshift.io/service-ca-hash"]
+	}
+
+	g.Expect(caHash("ca-1")).To(Equal(caHash("ca-1")))
+	g.Expect(caHash("ca-1")).NotTo(Equal(caHash("ca-2")))
+
+	// on a standalone cluster, the CA is the one the service CA operator injects in the namespace
+	standaloneAnnotations := func(clusterObjs ...crclient.Object) map[string]string {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(),
+			cnofake.NewFakeClient(clusterObjs...), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		return getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Annotations
+	}
+	standaloneCAHash := func(ca string) string {
+		annotations := standaloneAnnotations(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-multus", Name: "openshift-service-ca.crt"},
+			Data:       map[string]string{"service-ca.crt": ca},
+		})
+		g.Expect(annotations).To(HaveKey("network.operator.openshift.io/service-ca-hash"))
+		return annotations["network.operator.openshift.io/service-ca-hash"]
+	}
+	g.Expect(standaloneCAHash("ca-1")).To(Equal(standaloneCAHash("ca-1")))
+	g.Expect(standaloneCAHash("ca-1")).NotTo(Equal(standaloneCAHash("ca-2")))
+	// until the CA is injected, there is nothing to hash
+	g.Expect(standaloneAnnotations()).NotTo(HaveKey("network.operator.openshift.io/service-ca-hash"))
+}
+
+// TestRenderMultusAdmissionControllerLogLevel tests the admission controller log verbosity
+func TestRenderMultusAdmissionControllerLogLevel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	command := func() string {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		return getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller").Command[2]
+	}
+
+	g.Expect(command()).NotTo(ContainSubstring("-v="))
+
+	for _, level := range []int{0, 5} {
+		bootstrapResult.MultusAdmissionController.LogLevel = &level
+		g.Expect(command()).To(ContainSubstring(fmt.Sprintf("-v=%d \\\n", level)))
+	}
+
+	level := 11
+	bootstrapResult.MultusAdmissionController.LogLevel = &level
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid log-level 11")))
+}
+
+// TestRequiredEnvVars tests the reported environment variables match the ones read by the render
+func TestRequiredEnvVars(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	source, err := os.ReadFile("multus_admission_controller.go")
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, match := range regexp.MustCompile(`os\.Getenv\("([A-Z_]+)"\)`).FindAllStringSubmatch(string(source), -1) {
+		g.Expect(multusAdmissionControllerEnvVars).To(ContainElement(match[1]))
+	}
+
+	t.Setenv("TOKEN_AUDIENCE", "secret-audience")
+	t.Setenv("TOKEN_MINTER_IMAGE", "")
+	os.Unsetenv("TOKEN_MINTER_IMAGE")
+	statuses := RequiredEnvVars()
+	g.Expect(statuses).To(HaveLen(len(multusAdmissionControllerEnvVars)))
+	g.Expect(statuses).To(ContainElement(EnvVarStatus{Name: "TOKEN_AUDIENCE", Present: true}))
+	g.Expect(statuses).To(ContainElement(EnvVarStatus{Name: "TOKEN_MINTER_IMAGE", Present: false}))
+}
+
+// TestRenderMultusAdmissionControllerMetricsAuth tests the metrics wiring for each metrics authentication mode
+func TestRenderMultusAdmissionControllerMetricsAuth(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testCases := []struct {
+		metricsAuth      string
+		proxy            bool
+		targetPort       string
+		scheme           string
+		metricsListenArg string
+	}{
+		{"", true, "https", "https", "-metrics-listen-address=127.0.0.1:9091"},
+		{"kube-rbac-proxy", true, "https", "https", "-metrics-listen-address=127.0.0.1:9091"},
+		{"none", false, "metrics-port", "http", "-metrics-listen-address=:9091"},
+	}
+	for _, tc := range testCases {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.MetricsAuth = tc.metricsAuth
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		podSpec := getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+		containers := []string{}
+		for _, c := range podSpec.Containers {
+			containers = append(containers, c.Name)
+		}
+		if tc.proxy {
+			g.Expect(containers).To(ConsistOf("multus-admission-controller", "kube-rbac-proxy"))
+		} else {
+			g.Expect(containers).To(ConsistOf("multus-admission-controller"))
+		}
+		g.Expect(getContainer(g, &podSpec, "multus-admission-controller").Command[2]).To(ContainSubstring(tc.metricsListenArg + " "))
+
+		for _, obj := range objs {
+			switch obj.GetKind() {
+			case "Service":
+				ports, _, err := uns.NestedSlice(obj.Object, "spec", "ports")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ports).To(ContainElement(HaveKeyWithValue("targetPort", tc.targetPort)))
+			case "ServiceMonitor":
+				endpoints, _, err := uns.NestedSlice(obj.Object, "spec", "endpoints")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(endpoints).To(ConsistOf(HaveKeyWithValue("scheme", tc.scheme)))
+			}
+		}
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.MetricsAuth = "basic"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid metrics-auth")))
+}
+
+// TestRenderMultusAdmissionControllerNamespacePolicy tests the HyperShift namespace handling
+func TestRenderMultusAdmissionControllerNamespacePolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("Namespace", "", "clusters-test")))
+
+	// the namespace is missing from the management cluster
+	client = cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "openshift-service-ca.crt",
+					Namespace: "clusters-test",
+				},
+				Data: map[string]string{
+					"service-ca.crt": "test-ca",
+				},
+			},
+		},
+	})
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("namespace clusters-test does not exist")))
+
+	bootstrapResult.MultusAdmissionController.NamespacePolicy = "render"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("Namespace", "", "clusters-test")))
+	for _, obj := range objs {
+		if obj.GetKind() == "Namespace" {
+			g.Expect(obj.GetAnnotations()).To(HaveKeyWithValue(names.ClusterNameAnnotation, names.ManagementClusterName))
+		}
+	}
+}
+
+// TestRenderMultusAdmissionControllerSessionAffinity tests the webhook Service session affinity
+func TestRenderMultusAdmissionControllerSessionAffinity(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	service := getMultusAdmissionControllerService(g, objs)
+	g.Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+	g.Expect(service.Spec.SessionAffinityConfig).To(BeNil())
+
+	timeout := 300
+	bootstrapResult.MultusAdmissionController.SessionAffinity = corev1.ServiceAffinityClientIP
+	bootstrapResult.MultusAdmissionController.SessionAffinityTimeout = &timeout
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	service = getMultusAdmissionControllerService(g, objs)
+	g.Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+	g.Expect(*service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(Equal(int32(300)))
+
+	timeout = 86401
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid session-affinity-timeout")))
+}
+
+// TestRenderMultusAdmissionControllerInternalTrafficPolicy tests the webhook Service internal
+// traffic policy
+func TestRenderMultusAdmissionControllerInternalTrafficPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	service := getMultusAdmissionControllerService(g, objs)
+	g.Expect(*service.Spec.InternalTrafficPolicy).To(Equal(corev1.ServiceInternalTrafficPolicyCluster))
+
+	for _, policy := range []corev1.ServiceInternalTrafficPolicy{corev1.ServiceInternalTrafficPolicyCluster, corev1.ServiceInternalTrafficPolicyLocal} {
+		bootstrapResult.MultusAdmissionController.InternalTrafficPolicy = policy
+		objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		service = getMultusAdmissionControllerService(g, objs)
+		g.Expect(*service.Spec.InternalTrafficPolicy).To(Equal(policy))
+	}
+
+	// Local with a single replica only warns
+	bootstrapResult.Infra.ControlPlaneTopology = configv1.SingleReplicaTopologyMode
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	service = getMultusAdmissionControllerService(g, objs)
+	g.Expect(*service.Spec.InternalTrafficPolicy).To(Equal(corev1.ServiceInternalTrafficPolicyLocal))
+
+	bootstrapResult.MultusAdmissionController.InternalTrafficPolicy = "Node"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid internal-traffic-policy")))
+}
+
+// TestRenderMultusAdmissionControllerTopologyMode tests the topology aware routing annotation of
+// the webhook Service
+func TestRenderMultusAdmissionControllerTopologyMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	masters := func(zones ...string) []crclient.Object {
+		var nodes []crclient.Object
+		for i, zone := range zones {
+			nodes = append(nodes, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("master-%d", i),
+				Labels: map[string]string{"node-role.kubernetes.io/master": "", corev1.LabelTopologyZone: zone},
+			}})
+		}
+		return nodes
+	}
+	renderTopologyMode := func(bootstrapResult *bootstrap.BootstrapResult, nodes []crclient.Object) (string, error) {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(nodes...), FeatureGateSet{})
+		if err != nil {
+			return "", err
+		}
+		return getMultusAdmissionControllerService(g, objs).Annotations["service.kubernetes.io/topology-mode"], nil
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	mode, err := renderTopologyMode(bootstrapResult, masters("zone-a", "zone-b", "zone-c"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mode).To(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.TopologyMode = "Auto"
+	mode, err = renderTopologyMode(bootstrapResult, masters("zone-a", "zone-b", "zone-c"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mode).To(Equal("Auto"))
+
+	// the hints are pointless with a single zone, or a single replica
+	mode, err = renderTopologyMode(bootstrapResult, masters("zone-a", "zone-a", "zone-a"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mode).To(BeEmpty())
+
+	bootstrapResult.Infra.ControlPlaneTopology = configv1.SingleReplicaTopologyMode
+	mode, err = renderTopologyMode(bootstrapResult, masters("zone-a", "zone-b", "zone-c"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mode).To(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.TopologyMode = "PreferZone"
+	_, err = renderTopologyMode(bootstrapResult, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid topology-mode")))
+}
+
+// TestRenderMultusAdmissionControllerDNS tests the admission controller pod DNS policy and config
+func TestRenderMultusAdmissionControllerDNS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployment := getMultusAdmissionControllerDeployment(g, objs)
+	g.Expect(deployment.Spec.Template.Spec.DNSPolicy).To(Equal(corev1.DNSClusterFirst))
+	g.Expect(deployment.Spec.Template.Spec.DNSConfig).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.DNSPolicy = corev1.DNSNone
+	bootstrapResult.MultusAdmissionController.DNSNameservers = []string{"10.0.0.10", "fd00::10"}
+	bootstrapResult.MultusAdmissionController.DNSSearches = []string{"cluster.local"}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployment = getMultusAdmissionControllerDeployment(g, objs)
+	g.Expect(deployment.Spec.Template.Spec.DNSPolicy).To(Equal(corev1.DNSNone))
+	g.Expect(deployment.Spec.Template.Spec.DNSConfig).To(Equal(&corev1.PodDNSConfig{
+		Nameservers: []string{"10.0.0.10", "fd00::10"},
+		Searches:    []string{"cluster.local"},
+	}))
+
+	bootstrapResult.MultusAdmissionController.DNSNameservers = nil
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("requires dns-nameservers")))
+}
+
+// TestRenderMultusAdmissionControllerIncompleteBootstrap tests that render fails with a descriptive
+// error on an incomplete bootstrap result
+func TestRenderMultusAdmissionControllerIncompleteBootstrap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, nil, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("bootstrap result is missing")))
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	delete(bootstrapResult.Infra.APIServers, bootstrap.APIServerDefaultLocal)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("missing Infra.APIServers[" + bootstrap.APIServerDefaultLocal + "]")))
+
+	bootstrapResult, client = fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.Infra.APIServers[bootstrap.APIServerDefaultLocal] = bootstrap.APIServer{Host: "api.example.com"}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("Infra.APIServers[" + bootstrap.APIServerDefaultLocal + "] has an empty port")))
+
+	bootstrapResult, client = fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.Infra.HostedControlPlane = nil
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("missing Infra.HostedControlPlane")))
+
+	bootstrapResult, client = fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.HyperShiftConfig.Namespace = ""
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("missing MultusAdmissionController.HyperShiftConfig.Namespace")))
+
+	// the hypershift fields aren't required outside of HyperShift
+	bootstrapResult = fakeBootstrapResult()
+	delete(bootstrapResult.Infra.APIServers, bootstrap.APIServerDefaultLocal)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+// TestRenderMultusAdmissionControllerRevisions tests the versioned admission controller Deployments
+func TestRenderMultusAdmissionControllerRevisions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getDeployments := func(objs []*uns.Unstructured) map[string]*uns.Unstructured {
+		deployments := map[string]*uns.Unstructured{}
+		for _, obj := range objs {
+			if obj.GetKind() == "Deployment" {
+				deployments[obj.GetName()] = obj
+			}
+		}
+		return deployments
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.Revision = "v2"
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployments := getDeployments(objs)
+	g.Expect(deployments).To(HaveLen(1))
+	g.Expect(deployments).To(HaveKey("multus-admission-controller-v2"))
+	deployment := &appsv1.Deployment{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(deployments["multus-admission-controller-v2"].Object, deployment)).To(Succeed())
+	g.Expect(deployment.Labels).To(HaveKeyWithValue(multusAdmissionControllerRevisionLabel, "v2"))
+	g.Expect(deployment.Spec.Selector.MatchLabels).To(HaveKeyWithValue(multusAdmissionControllerRevisionLabel, "v2"))
+	g.Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(multusAdmissionControllerRevisionLabel, "v2"))
+	// the shared Service selects the pods of every revision
+	service := getMultusAdmissionControllerService(g, objs)
+	g.Expect(service.Spec.Selector).NotTo(HaveKey(multusAdmissionControllerRevisionLabel))
+
+	// the previous revision is retained, but not applied
+	bootstrapResult.MultusAdmissionController.PreviousRevision = "v1"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployments = getDeployments(objs)
+	g.Expect(deployments).To(HaveLen(2))
+	g.Expect(deployments["multus-admission-controller-v2"].GetAnnotations()).NotTo(HaveKey(names.CreateWaitAnnotation))
+	g.Expect(deployments["multus-admission-controller-v1"].GetAnnotations()).To(HaveKey(names.CreateWaitAnnotation))
+	g.Expect(deployments["multus-admission-controller-v1"].GetLabels()).To(HaveKeyWithValue(multusAdmissionControllerRevisionLabel, "v1"))
+
+	// the previous revision is only pruned once the current revision is available
+	bootstrapResult.MultusAdmissionController.PrunePreviousRevision = true
+	replicas := int32(2)
+	current := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "multus-admission-controller-v2",
+			Namespace: "openshift-multus",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas:   2,
+			AvailableReplicas: 1,
+		},
+	}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(current.DeepCopy()), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getDeployments(objs)).To(HaveLen(2))
+
+	current.Status.AvailableReplicas = 2
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(current.DeepCopy()), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployments = getDeployments(objs)
+	g.Expect(deployments).To(HaveLen(1))
+	g.Expect(deployments).To(HaveKey("multus-admission-controller-v2"))
+
+	// without a client for the cluster, the previous revision is retained
+	var unavailableErr *ManagementClusterUnavailableError
+	_, err = multusAdmissionControllerDeploymentAvailable(cnofake.NewFakeClient(current.DeepCopy()), names.ManagementClusterName, "openshift-multus", "multus-admission-controller-v2")
+	g.Expect(errors.As(err, &unavailableErr)).To(BeTrue())
+
+	bootstrapResult.MultusAdmissionController.PreviousRevision = "v2"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("must differ from revision")))
+}
+
+// TestRenderMultusAdmissionControllerRollingUpdate tests the admission controller rollout strategy
+func TestRenderMultusAdmissionControllerRollingUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployment := getMultusAdmissionControllerDeployment(g, objs)
+	g.Expect(deployment.Spec.Strategy.RollingUpdate).To(BeNil())
+
+	// zero downtime: never take down a webhook replica before its replacement is up
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromInt(1)
+	bootstrapResult.MultusAdmissionController.MaxUnavailable = &maxUnavailable
+	bootstrapResult.MultusAdmissionController.MaxSurge = &maxSurge
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployment = getMultusAdmissionControllerDeployment(g, objs)
+	g.Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+	g.Expect(*deployment.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(intstr.FromInt(0)))
+	g.Expect(*deployment.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(intstr.FromInt(1)))
+
+	// the unset value defaults to 25%
+	bootstrapResult.MultusAdmissionController.MaxSurge = nil
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	deployment = getMultusAdmissionControllerDeployment(g, objs)
+	g.Expect(*deployment.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(intstr.FromString("25%")))
+
+	maxSurge = intstr.FromString("0%")
+	bootstrapResult.MultusAdmissionController.MaxSurge = &maxSurge
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("both may not be 0")))
+
+	maxSurge = intstr.FromString("many")
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid max-surge")))
+}
+
+// TestManagementClusterUnavailableError tests that management cluster outages are distinguished
+// from other render errors
+func TestManagementClusterUnavailableError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// no management cluster client
+	bootstrapResult, _ := fakeMultusAdmissionControllerHyperShift()
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	var mgmtErr *ManagementClusterUnavailableError
+	g.Expect(errors.As(err, &mgmtErr)).To(BeTrue())
+
+	// a missing service CA is a configuration problem
+	bootstrapResult, _ = fakeMultusAdmissionControllerHyperShift()
+	client := cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "clusters-test"}},
+		},
+	})
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.As(err, &mgmtErr)).To(BeFalse())
+
+	g.Expect(errors.As(newManagementClusterError(fmt.Errorf("dial tcp: connection refused")), &mgmtErr)).To(BeTrue())
+	g.Expect(errors.As(newManagementClusterError(apierrors.NewServiceUnavailable("unavailable")), &mgmtErr)).To(BeTrue())
+	g.Expect(errors.As(newManagementClusterError(apierrors.NewForbidden(corev1.Resource("configmaps"), "test", nil)), &mgmtErr)).To(BeFalse())
+}
+
+// TestRenderMultusAdmissionControllerServiceAccountName tests that every rendered reference to the
+// admission controller ServiceAccount uses the configured name
+func TestRenderMultusAdmissionControllerServiceAccountName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, hyperShift := range []bool{false, true} {
+		bootstrapResult, client := fakeBootstrapResult(), cnoclient.Client(cnofake.NewFakeClient())
+		if hyperShift {
+			bootstrapResult, client = fakeMultusAdmissionControllerHyperShift()
+		}
+		bootstrapResult.MultusAdmissionController.ServiceAccountName = "custom-multus-ac"
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, hyperShift, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objs).To(ContainElement(HaveKubernetesID("ServiceAccount", "openshift-multus", "custom-multus-ac")))
+
+		for _, obj := range objs {
+			if obj.GetKind() != "ClusterRoleBinding" {
+				continue
+			}
+			subjects, _, err := uns.NestedSlice(obj.Object, "subjects")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subjects).To(ConsistOf(HaveKeyWithValue("name", "custom-multus-ac")))
+		}
+
+		deployment := getMultusAdmissionControllerDeployment(g, objs)
+		if hyperShift {
+			g.Expect(getContainer(g, &deployment.Spec.Template.Spec, "hosted-cluster-token").Args).To(
+				ContainElement("--service-account-name=custom-multus-ac"))
+		} else {
+			g.Expect(deployment.Spec.Template.Spec.ServiceAccountName).To(Equal("custom-multus-ac"))
+		}
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.ServiceAccountName = "Invalid_Name"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid service-account-name")))
+}
+
+// TestFilterChangedMultusAdmissionControllerObjects tests that only the objects changed since the
+// applied content generations are returned
+func TestFilterChangedMultusAdmissionControllerObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// everything changed since nothing was applied
+	changed, applied, err := FilterChangedMultusAdmissionControllerObjects(objs, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(HaveLen(len(objs)))
+	g.Expect(applied).To(HaveLen(len(objs)))
+	for _, obj := range changed {
+		g.Expect(obj.GetAnnotations()).To(HaveKey(names.ContentGenerationAnnotation))
+	}
+
+	// identical input
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	changed, generations, err := FilterChangedMultusAdmissionControllerObjects(objs, applied)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeEmpty())
+	g.Expect(generations).To(Equal(applied))
+
+	// tagging is idempotent
+	changed, _, err = FilterChangedMultusAdmissionControllerObjects(objs, applied)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeEmpty())
+
+	// changed input
+	bootstrapResult.MultusAdmissionController.SessionAffinity = corev1.ServiceAffinityClientIP
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	changed, _, err = FilterChangedMultusAdmissionControllerObjects(objs, applied)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(HaveLen(1))
+	g.Expect(changed[0].GetKind()).To(Equal("Service"))
+}
+
+// TestRenderMultusAdmissionControllerAutomountServiceAccountToken tests the pod
+// automountServiceAccountToken override
+func TestRenderMultusAdmissionControllerAutomountServiceAccountToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	hasTokenVolume := func(podSpec *corev1.PodSpec) bool {
+		for _, volume := range podSpec.Volumes {
+			if volume.Name == "kube-api-access" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// defaults
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec := &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(podSpec.AutomountServiceAccountToken).To(BeNil())
+	g.Expect(hasTokenVolume(podSpec)).To(BeFalse())
+
+	hyperShiftBootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, hyperShiftBootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(*podSpec.AutomountServiceAccountToken).To(BeFalse())
+
+	// disabled, the token is projected for in-cluster auth
+	automount := false
+	bootstrapResult.MultusAdmissionController.AutomountServiceAccountToken = &automount
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(*podSpec.AutomountServiceAccountToken).To(BeFalse())
+	g.Expect(hasTokenVolume(podSpec)).To(BeTrue())
+	for _, name := range []string{"multus-admission-controller", "kube-rbac-proxy"} {
+		g.Expect(getContainer(g, podSpec, name).VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "kube-api-access",
+			MountPath: "/var/run/secrets/kubernetes.io/serviceaccount",
+			ReadOnly:  true,
+		}))
+	}
+
+	// enabled
+	automount = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(*podSpec.AutomountServiceAccountToken).To(BeTrue())
+	g.Expect(hasTokenVolume(podSpec)).To(BeFalse())
+}
+
+// TestManagedGVKs tests that every kind the admission controller render produces is listed,
+// with every optional kind enabled, and that every listed kind is produced by some render
+func TestManagedGVKs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gvks := ManagedGVKs()
+	produced := map[schema.GroupVersionKind]bool{}
+	var check func(objs []*uns.Unstructured)
+	check = func(objs []*uns.Unstructured) {
+		for _, obj := range objs {
+			g.Expect(gvks).To(ContainElement(obj.GroupVersionKind()))
+			produced[obj.GroupVersionKind()] = true
+			if obj.GetKind() != "Template" {
+				continue
+			}
+			// the objects wrapped by a Template are applied by whoever processes it
+			template := &templatev1.Template{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, template)).To(Succeed())
+			wrapped := []*uns.Unstructured{}
+			for _, raw := range template.Objects {
+				object := &uns.Unstructured{}
+				g.Expect(object.UnmarshalJSON(raw.Raw)).To(Succeed())
+				wrapped = append(wrapped, object)
+			}
+			check(wrapped)
+		}
+	}
+	withOptionalKinds := func(bootstrapResult *bootstrap.BootstrapResult) {
+		bootstrapResult.MultusAdmissionController.Autoscaling = true
+		bootstrapResult.MultusAdmissionController.MetricsService = true
+		bootstrapResult.MultusAdmissionController.SplitRBAC = true
+		bootstrapResult.MultusAdmissionController.RoleAggregation = true
+	}
+	hpaClient := func(client cnoclient.Client) cnoclient.Client {
+		client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: "autoscaling/v2",
+			APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers", Namespaced: true, Kind: "HorizontalPodAutoscaler"}},
+		}}
+		return client
+	}
+
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	check(objs)
+
+	bootstrapResult := fakeBootstrapResult()
+	withOptionalKinds(bootstrapResult)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(cnofake.NewFakeClient()), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("HorizontalPodAutoscaler", "openshift-multus", "multus-admission-controller")))
+	check(objs)
+
+	bootstrapResult.MultusAdmissionController.OutputFormat = "Template"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(cnofake.NewFakeClient()), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(HaveLen(1))
+	check(objs)
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.NamespacePolicy = multusAdmissionControllerNamespaceRender
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	check(objs)
+
+	withOptionalKinds(bootstrapResult)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, hpaClient(client), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	check(objs)
+
+	t.Setenv("RHOBS_MONITORING", "1")
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	check(objs)
+
+	for _, gvk := range gvks {
+		g.Expect(produced).To(HaveKey(gvk), "%s is listed but never rendered", gvk)
+	}
+}
+
+// TestRenderMultusAdmissionControllerSideEffects tests the validating webhook sideEffects
+func TestRenderMultusAdmissionControllerSideEffects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getSideEffects := func(objs []*uns.Unstructured) []admissionregistrationv1.SideEffectClass {
+		sideEffects := []admissionregistrationv1.SideEffectClass{}
+		for _, obj := range objs {
+			if obj.GetKind() != "ValidatingWebhookConfiguration" {
+				continue
+			}
+			webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhookConfig)).To(Succeed())
+			for _, webhook := range webhookConfig.Webhooks {
+				g.Expect(webhook.SideEffects).NotTo(BeNil())
+				sideEffects = append(sideEffects, *webhook.SideEffects)
+			}
+		}
+		return sideEffects
+	}
+
+	// the default is safe for server-side dry-run
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSideEffects(objs)).To(ConsistOf(admissionregistrationv1.SideEffectClassNoneOnDryRun))
+
+	bootstrapResult.MultusAdmissionController.SideEffects = admissionregistrationv1.SideEffectClassNone
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSideEffects(objs)).To(ConsistOf(admissionregistrationv1.SideEffectClassNone))
+
+	bootstrapResult.MultusAdmissionController.SideEffects = admissionregistrationv1.SideEffectClassUnknown
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid side-effects")))
+}
+
+// TestFeatureGateSet tests the feature gates threaded into the admission controller render
+func TestFeatureGateSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	set := NewFeatureGateSet(featuregates.NewFeatureGate(
+		[]configv1.FeatureGateName{configv1.FeatureGateAdminNetworkPolicy},
+		[]configv1.FeatureGateName{configv1.FeatureGateGatewayAPI}))
+	g.Expect(set.Enabled(configv1.FeatureGateAdminNetworkPolicy)).To(BeTrue())
+	g.Expect(set.Enabled(configv1.FeatureGateGatewayAPI)).To(BeFalse())
+	// unknown gates are disabled rather than a panic
+	g.Expect(set.Enabled("UnknownGate")).To(BeFalse())
+	g.Expect(NewFeatureGateSet(nil)).To(BeEmpty())
+
+	objs, err := renderMultusAdmissionController(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(),
+		featuregates.NewFeatureGate([]configv1.FeatureGateName{configv1.FeatureGateAdminNetworkPolicy}, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
+}
+
+// TestRenderMultusAdmissionControllerMetricsPortPath tests the metrics port and path through the
+// Service, the kube-rbac-proxy and the ServiceMonitor
+func TestRenderMultusAdmissionControllerMetricsPortPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	metricsPort := 9443
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.MetricsPort = &metricsPort
+	bootstrapResult.MultusAdmissionController.MetricsPath = "/custom/metrics"
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	service := getMultusAdmissionControllerService(g, objs)
+	g.Expect(service.Spec.Ports).To(ContainElement(And(
+		HaveField("Name", "metrics"),
+		HaveField("Port", int32(9443)),
+	)))
+
+	proxy := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "kube-rbac-proxy")
+	g.Expect(proxy.Args).To(ContainElements("--secure-listen-address=:9443", "--allow-paths=/custom/metrics"))
+	g.Expect(proxy.Ports).To(ConsistOf(HaveField("ContainerPort", int32(9443))))
+
+	found := false
+	for _, obj := range objs {
+		if obj.GetKind() != "ServiceMonitor" {
+			continue
+		}
+		found = true
+		endpoints, _, err := uns.NestedSlice(obj.Object, "spec", "endpoints")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(endpoints).To(ConsistOf(And(
+			HaveKeyWithValue("port", "metrics"),
+			HaveKeyWithValue("path", "/custom/metrics"),
+		)))
+	}
+	g.Expect(found).To(BeTrue())
+
+	metricsPort = 6443
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("the webhook port and the metrics port (metrics-port) are both 6443")))
+}
+
+// TestSelectServiceCA tests that the most current service CA ConfigMap is selected when the
+// hosted control plane namespace has none
+func TestSelectServiceCA(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	older := string(generateTestCA(g, "older", time.Now().Add(-2*time.Hour)))
+	newer := string(generateTestCA(g, "newer", time.Now().Add(-time.Hour)))
+	serviceCA := func(namespace, ca string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "openshift-service-ca.crt",
+				Namespace: namespace,
+			},
+			Data: map[string]string{
+				"service-ca.crt": ca,
+			},
+		}
+	}
+
+	g.Expect(selectServiceCA(nil, "service-ca.crt")).To(BeNil())
+	g.Expect(selectServiceCA([]corev1.ConfigMap{*serviceCA("stale", older), *serviceCA("fresh", newer)}, "service-ca.crt").Namespace).To(Equal("fresh"))
+	g.Expect(selectServiceCA([]corev1.ConfigMap{*serviceCA("fresh", newer), *serviceCA("stale", older)}, "service-ca.crt").Namespace).To(Equal("fresh"))
+	// a rotated bundle has more certificates
+	g.Expect(selectServiceCA([]corev1.ConfigMap{*serviceCA("stale", newer), *serviceCA("rotated", older+newer)}, "service-ca.crt").Namespace).To(Equal("rotated"))
+
+	bootstrapResult, _ := fakeMultusAdmissionControllerHyperShift()
+	client := cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "clusters-test"}},
+			serviceCA("stale", older),
+			serviceCA("fresh", newer),
+		},
+	})
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	caHash := sha1.Sum([]byte(newer))
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Annotations).To(
+		HaveKeyWithValue("network.operator.openshift.io/service-ca-hash", hex.EncodeToString(caHash[:])))
+}
+
+// TestRenderMultusAdmissionControllerWebhookRegistration tests the two phase webhook registration
+func TestRenderMultusAdmissionControllerWebhookRegistration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	webhookIndex := func(objs []*uns.Unstructured) int {
+		for i, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				return i
+			}
+		}
+		return -1
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	phase1, phase2 := SplitMultusAdmissionControllerWebhookPhase(objs)
+	g.Expect(phase2).To(HaveLen(1))
+	g.Expect(phase2[0].GetKind()).To(Equal("ValidatingWebhookConfiguration"))
+	g.Expect(phase1).To(HaveLen(len(objs) - 1))
+	g.Expect(phase1).To(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
+	// webhook registration is immediate by default
+	g.Expect(objs[webhookIndex(objs)].GetAnnotations()).NotTo(HaveKey(names.CreateWaitAnnotation))
+
+	// held back until the controller endpoints are ready
+	bootstrapResult.MultusAdmissionController.WebhookRegistration = multusAdmissionControllerWebhookRegistrationAfterReady
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(webhookIndex(objs)).To(Equal(len(objs) - 1))
+	g.Expect(objs[webhookIndex(objs)].GetAnnotations()).To(HaveKey(names.CreateWaitAnnotation))
+
+	// an existing webhook configuration keeps being updated, say with a new caBundle, while the
+	// endpoints aren't ready
+	existing := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: names.MULTUS_VALIDATING_WEBHOOK},
+	}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(existing), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(webhookIndex(objs)).To(Equal(len(objs) - 1))
+	g.Expect(objs[webhookIndex(objs)].GetAnnotations()).NotTo(HaveKey(names.CreateWaitAnnotation))
+
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "multus-admission-controller",
+			Namespace: "openshift-multus",
+		},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.128.0.10"}},
+		}},
+	}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(endpoints), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(webhookIndex(objs)).To(Equal(len(objs) - 1))
+	g.Expect(objs[webhookIndex(objs)].GetAnnotations()).NotTo(HaveKey(names.CreateWaitAnnotation))
+}
+
+// TestRenderMultusAdmissionControllerScratchVolumeSizeLimit tests the sizeLimit of the emptyDir
+// scratch volumes
+func TestRenderMultusAdmissionControllerScratchVolumeSizeLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	emptyDirSizeLimits := func(bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, externalControlPlane bool) (map[string]string, error) {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, externalControlPlane, bootstrapResult, client, FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		sizeLimits := map[string]string{}
+		for _, volume := range getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				g.Expect(volume.EmptyDir.SizeLimit).NotTo(BeNil(), "volume %s", volume.Name)
+				sizeLimits[volume.Name] = volume.EmptyDir.SizeLimit.String()
+			}
+		}
+		return sizeLimits, nil
+	}
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.AuditLogDir = "/var/log/multus-admission-controller"
+	sizeLimits, err := emptyDirSizeLimits(bootstrapResult, client, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sizeLimits).To(Equal(map[string]string{
+		"audit-log":                 "100Mi",
+		"hosted-cluster-api-access": "100Mi",
+	}))
+
+	sizeLimit := resource.MustParse("20Mi")
+	bootstrapResult.MultusAdmissionController.ScratchVolumeSizeLimit = &sizeLimit
+	sizeLimits, err = emptyDirSizeLimits(bootstrapResult, client, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sizeLimits).To(HaveEach("20Mi"))
+
+	for _, invalid := range []string{"0", "2Gi"} {
+		sizeLimit := resource.MustParse(invalid)
+		bootstrapResult.MultusAdmissionController.ScratchVolumeSizeLimit = &sizeLimit
+		_, err = emptyDirSizeLimits(bootstrapResult, client, true)
+		g.Expect(err).To(MatchError(ContainSubstring("invalid scratch-volume-size-limit")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerPreStopSleep tests the preStop hook draining the admission
+// reviews in flight
+func TestRenderMultusAdmissionControllerPreStopSleep(t *testing.T) {
+	g := NewGomegaWithT(t)
+	intPtr := func(i int) *int { return &i }
+
+	renderLifecycle := func(seconds *int) (*corev1.Lifecycle, error) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.PreStopSleepSeconds = seconds
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		return getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller").Lifecycle, nil
+	}
+
+	lifecycle, err := renderLifecycle(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lifecycle.PreStop.Exec.Command).To(Equal([]string{"sleep", "5"}))
+
+	lifecycle, err = renderLifecycle(intPtr(15))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lifecycle.PreStop.Exec.Command).To(Equal([]string{"sleep", "15"}))
+
+	lifecycle, err = renderLifecycle(intPtr(0))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lifecycle).To(BeNil())
+
+	for _, seconds := range []int{-1, 30} {
+		_, err = renderLifecycle(intPtr(seconds))
+		g.Expect(err).To(MatchError(ContainSubstring("invalid pre-stop-sleep-seconds")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerShutdownTimeout tests the shutdown timeout argument, and that
+// it fits in the termination grace period with the preStop sleep
+func TestRenderMultusAdmissionControllerShutdownTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+	intPtr := func(i int) *int { return &i }
+	durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+	renderCommand := func(timeout *time.Duration, preStopSleep *int) (string, error) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.ShutdownTimeout = timeout
+		bootstrapResult.MultusAdmissionController.PreStopSleepSeconds = preStopSleep
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return "", err
+		}
+		container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+		return strings.Join(container.Command, " "), nil
+	}
+
+	command, err := renderCommand(nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(command).NotTo(ContainSubstring("-shutdown-timeout"))
+
+	command, err = renderCommand(durationPtr(20*time.Second), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(command).To(ContainSubstring("-shutdown-timeout=20s"))
+
+	// 28s fits with the preStop hook disabled, but not with the default 5s sleep
+	command, err = renderCommand(durationPtr(28*time.Second), intPtr(0))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(command).To(ContainSubstring("-shutdown-timeout=28s"))
+	_, err = renderCommand(durationPtr(28*time.Second), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("must not exceed the 30s termination grace period")))
+
+	_, err = renderCommand(durationPtr(0), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid shutdown-timeout 0s: must be positive")))
+}
+
+// TestRenderMultusAdmissionControllerAutoscaling tests the admission controller
+// HorizontalPodAutoscaler, and that it replaces the static replica count
+func TestRenderMultusAdmissionControllerAutoscaling(t *testing.T) {
+	g := NewGomegaWithT(t)
+	intPtr := func(i int) *int { return &i }
+
+	hpaClient := func() cnoclient.Client {
+		client := cnofake.NewFakeClient()
+		client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: "autoscaling/v2",
+			APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers", Namespaced: true, Kind: "HorizontalPodAutoscaler"}},
+		}}
+		return client
+	}
+	getHPA := func(objs []*uns.Unstructured) *autoscalingv2.HorizontalPodAutoscaler {
+		for _, obj := range objs {
+			if obj.GetKind() == "HorizontalPodAutoscaler" {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, hpa)).To(Succeed())
+				return hpa
+			}
+		}
+		return nil
+	}
+	utilization := func(name corev1.ResourceName, percent int32) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   name,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &percent},
+			},
+		}
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getHPA(objs)).To(BeNil())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Replicas).To(HaveValue(Equal(int32(2))))
+
+	bootstrapResult.MultusAdmissionController.Autoscaling = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Replicas).To(BeNil())
+	for _, obj := range objs {
+		g.Expect(obj.GetKind()).NotTo(BeEmpty())
+	}
+	hpa := getHPA(objs)
+	g.Expect(hpa).NotTo(BeNil())
+	g.Expect(hpa.Namespace).To(Equal("openshift-multus"))
+	g.Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "multus-admission-controller",
+	}))
+	g.Expect(hpa.Spec.MinReplicas).To(HaveValue(Equal(int32(2))))
+	g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(6)))
+	g.Expect(hpa.Spec.Metrics).To(Equal([]autoscalingv2.MetricSpec{utilization(corev1.ResourceCPU, 80)}))
+
+	bootstrapResult.MultusAdmissionController.AutoscalingMinReplicas = intPtr(3)
+	bootstrapResult.MultusAdmissionController.AutoscalingMaxReplicas = intPtr(10)
+	bootstrapResult.MultusAdmissionController.AutoscalingMemoryUtilization = intPtr(70)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	hpa = getHPA(objs)
+	g.Expect(hpa.Spec.MinReplicas).To(HaveValue(Equal(int32(3))))
+	g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(10)))
+	g.Expect(hpa.Spec.Metrics).To(Equal([]autoscalingv2.MetricSpec{utilization(corev1.ResourceMemory, 70)}))
+
+	bootstrapResult.MultusAdmissionController.AutoscalingCPUUtilization = intPtr(60)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getHPA(objs).Spec.Metrics).To(Equal([]autoscalingv2.MetricSpec{
+		utilization(corev1.ResourceCPU, 60),
+		utilization(corev1.ResourceMemory, 70),
+	}))
+
+	// the autoscaler follows the Deployment revision
+	bootstrapResult.MultusAdmissionController.Revision = "v2"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getHPA(objs).Spec.ScaleTargetRef.Name).To(Equal("multus-admission-controller-v2"))
+	bootstrapResult.MultusAdmissionController.Revision = ""
+
+	// without autoscaling/v2 the Deployment keeps its static replica count
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getHPA(objs)).To(BeNil())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Replicas).To(HaveValue(Equal(int32(2))))
+
+	bootstrapResult.MultusAdmissionController.AutoscalingMaxReplicas = intPtr(2)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid autoscaling-max-replicas 2: must not be less than the minimum replicas 3")))
+
+	bootstrapResult = fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.Autoscaling = true
+	bootstrapResult.MultusAdmissionController.Paused = true
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("autoscaling conflicts with paused")))
+
+	bootstrapResult = fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.AutoscalingMinReplicas = intPtr(2)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("autoscaling-min-replicas requires autoscaling")))
+
+	bootstrapResult.MultusAdmissionController.Autoscaling = true
+	bootstrapResult.MultusAdmissionController.AutoscalingMinReplicas = intPtr(0)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, hpaClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid autoscaling-min-replicas 0")))
+}
+
+// TestRenderMultusAdmissionControllerEphemeralStorage tests the admission controller container
+// ephemeral storage request and limit
+func TestRenderMultusAdmissionControllerEphemeralStorage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderResources := func(request, limit string) (*corev1.ResourceRequirements, error) {
+		bootstrapResult := fakeBootstrapResult()
+		if request != "" {
+			q := resource.MustParse(request)
+			bootstrapResult.MultusAdmissionController.EphemeralStorageRequest = &q
+		}
+		if limit != "" {
+			q := resource.MustParse(limit)
+			bootstrapResult.MultusAdmissionController.EphemeralStorageLimit = &q
+		}
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		return &getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller").Resources, nil
+	}
+
+	resources, err := renderResources("", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources.Requests.StorageEphemeral().String()).To(Equal("50Mi"))
+	g.Expect(resources.Limits.StorageEphemeral().String()).To(Equal("1Gi"))
+	// the other requests are unchanged
+	g.Expect(resources.Requests.Memory().String()).To(Equal("50Mi"))
+
+	resources, err = renderResources("100Mi", "2Gi")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources.Requests.StorageEphemeral().String()).To(Equal("100Mi"))
+	g.Expect(resources.Limits.StorageEphemeral().String()).To(Equal("2Gi"))
+
+	resources, err = renderResources("1Gi", "1Gi")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources.Limits.StorageEphemeral().String()).To(Equal("1Gi"))
+
+	_, err = renderResources("200Mi", "100Mi")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid ephemeral-storage-limit 100Mi: must not be less than ephemeral-storage-request 200Mi")))
+	// against the default limit
+	_, err = renderResources("2Gi", "")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid ephemeral-storage-limit 1Gi")))
+	_, err = renderResources("-1Mi", "")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid ephemeral-storage-request")))
+}
+
+// TestRenderMultusAdmissionControllerProbes tests the readiness and liveness probe timings
+func TestRenderMultusAdmissionControllerProbes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	intPtr := func(i int) *int { return &i }
+
+	// no probes by default
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.ReadinessProbe).To(BeNil())
+	g.Expect(container.StartupProbe).To(BeNil())
+	g.Expect(container.LivenessProbe).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.HealthProbes = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.ReadinessProbe.PeriodSeconds).To(Equal(int32(10)))
+	g.Expect(container.ReadinessProbe.FailureThreshold).To(Equal(int32(3)))
+	g.Expect(container.LivenessProbe.FailureThreshold).To(Equal(int32(9)))
+
+	bootstrapResult.MultusAdmissionController.ProbeInitialDelaySeconds = intPtr(60)
+	bootstrapResult.MultusAdmissionController.ProbePeriodSeconds = intPtr(30)
+	bootstrapResult.MultusAdmissionController.ProbeTimeoutSeconds = intPtr(10)
+	bootstrapResult.MultusAdmissionController.ProbeFailureThreshold = intPtr(6)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe} {
+		g.Expect(probe.InitialDelaySeconds).To(Equal(int32(60)))
+		g.Expect(probe.PeriodSeconds).To(Equal(int32(30)))
+		g.Expect(probe.TimeoutSeconds).To(Equal(int32(10)))
+	}
+	g.Expect(container.ReadinessProbe.FailureThreshold).To(Equal(int32(6)))
+	g.Expect(container.LivenessProbe.FailureThreshold).To(Equal(int32(18)))
+
+	bootstrapResult.MultusAdmissionController.ProbePeriodSeconds = intPtr(0)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid probe-period-seconds")))
+
+	// the timeout is checked against the default period
+	bootstrapResult.MultusAdmissionController.ProbePeriodSeconds = nil
+	bootstrapResult.MultusAdmissionController.ProbeTimeoutSeconds = intPtr(20)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("must not be greater than probe-period-seconds")))
+}
+
+// TestRenderMultusAdmissionControllerObjectSize tests that render fails rather than producing an
+// object too large for etcd
+func TestRenderMultusAdmissionControllerObjectSize(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	namespaces := make([]string, 50000)
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("openshift-pathological-namespace-%d", i)
+	}
+	ignoredNamespaces = strings.Join(namespaces, ",")
+	ignoredNamespacesUpdated = time.Now()
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("rendered Deployment openshift-multus/multus-admission-controller is")))
+}
+
+// TestRenderMultusAdmissionControllerRuntimeClass tests the pods RuntimeClass, and that render
+// fails when the RuntimeClass doesn't exist
+func TestRenderMultusAdmissionControllerRuntimeClass(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.RuntimeClassName).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.RuntimeClassName = "kata"
+	client := cnofake.NewFakeClient(&nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kata"},
+		Handler:    "kata",
+	})
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.RuntimeClassName).To(HaveValue(Equal("kata")))
+
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("RuntimeClass kata does not exist")))
+
+	bootstrapResult.MultusAdmissionController.RuntimeClassName = "Kata"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid runtime-class-name")))
+}
+
+// TestRenderMultusAdmissionControllerSeccompProfile tests the containers seccomp profile
+func TestRenderMultusAdmissionControllerSeccompProfile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec := &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	for _, name := range []string{"multus-admission-controller", "kube-rbac-proxy"} {
+		g.Expect(getContainer(g, podSpec, name).SecurityContext.SeccompProfile).To(Equal(&corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		}))
+	}
+
+	profile := "profiles/multus-admission-controller.json"
+	bootstrapResult.MultusAdmissionController.SeccompProfileType = corev1.SeccompProfileTypeLocalhost
+	bootstrapResult.MultusAdmissionController.SeccompLocalhostProfile = profile
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	for _, name := range []string{"multus-admission-controller", "kube-rbac-proxy"} {
+		g.Expect(getContainer(g, podSpec, name).SecurityContext.SeccompProfile).To(Equal(&corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: &profile,
+		}))
+	}
+
+	bootstrapResult.MultusAdmissionController.SeccompLocalhostProfile = "/var/lib/kubelet/seccomp/profile.json"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid seccomp-localhost-profile")))
+}
+
+// TestMultusAdmissionControllerRenderMutators tests that the registered mutators run in order and
+// that an error aborts the render
+func TestMultusAdmissionControllerRenderMutators(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Cleanup(func() { multusAdmissionControllerRenderMutators = nil })
+
+	calls := []string{}
+	RegisterMultusAdmissionControllerRenderMutator(func(objs []*uns.Unstructured) ([]*uns.Unstructured, error) {
+		calls = append(calls, "label")
+		for _, obj := range objs {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["distribution"] = "test"
+			obj.SetLabels(labels)
+		}
+		return objs, nil
+	})
+	RegisterMultusAdmissionControllerRenderMutator(func(objs []*uns.Unstructured) ([]*uns.Unstructured, error) {
+		calls = append(calls, "add")
+		// changes by an earlier mutator are visible to later ones
+		for _, obj := range objs {
+			g.Expect(obj.GetLabels()).To(HaveKeyWithValue("distribution", "test"))
+		}
+		cm := &uns.Unstructured{}
+		cm.SetAPIVersion("v1")
+		cm.SetKind("ConfigMap")
+		cm.SetName("distribution-config")
+		cm.SetNamespace("openshift-multus")
+		return append(objs, cm), nil
+	})
+
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal([]string{"label", "add"}))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ConfigMap", "openshift-multus", "distribution-config")))
+
+	RegisterMultusAdmissionControllerRenderMutator(func(objs []*uns.Unstructured) ([]*uns.Unstructured, error) {
+		return nil, fmt.Errorf("registry unavailable")
+	})
+	calls = []string{}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("registry unavailable")))
+	g.Expect(calls).To(Equal([]string{"label", "add"}))
+}
+
+// TestRenderMultusAdmissionControllerMultusAffinity tests the preferred affinity to multus pods
+func TestRenderMultusAdmissionControllerMultusAffinity(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.Affinity).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.MultusAffinity = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	affinity := getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.Affinity
+	g.Expect(affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+	g.Expect(affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(ConsistOf(
+		HaveField("PodAffinityTerm.LabelSelector.MatchLabels", HaveKeyWithValue("app", "multus")),
+	))
+
+	// multus doesn't run in the management cluster
+	hyperShiftBootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	hyperShiftBootstrapResult.MultusAdmissionController.MultusAffinity = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, hyperShiftBootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, term := range getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		g.Expect(term.PodAffinityTerm.LabelSelector.MatchLabels).NotTo(HaveKey("app"))
+	}
+}
+
+// TestValidateMultusAdmissionControllerSchema tests the validation of rendered objects against
+// the OpenAPI schema
+func TestValidateMultusAdmissionControllerSchema(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	doc, err := openapi_v2.ParseDocument([]byte(`{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "definitions": {
+    "io.test.v1.Widget": {
+      "type": "object",
+      "properties": {
+        "apiVersion": {"type": "string"},
+        "kind": {"type": "string"},
+        "metadata": {
+          "type": "object",
+          "properties": {
+            "name": {"type": "string"},
+            "namespace": {"type": "string"}
+          }
+        },
+        "spec": {
+          "type": "object",
+          "properties": {
+            "replicas": {"type": "integer"}
+          }
+        }
+      },
+      "x-kubernetes-group-version-kind": [{"group": "test.io", "kind": "Widget", "version": "v1"}]
+    }
+  }
+}`))
+	g.Expect(err).NotTo(HaveOccurred())
+	models, err := proto.NewOpenAPIData(doc)
+	g.Expect(err).NotTo(HaveOccurred())
+	parser, err := managedfields.NewGVKParser(models, false)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	widget := func(spec map[string]interface{}) *uns.Unstructured {
+		return &uns.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "test.io/v1",
+			"kind":       "Widget",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "openshift-multus",
+			},
+			"spec": spec,
+		}}
+	}
+
+	g.Expect(validateMultusAdmissionControllerSchema(widget(map[string]interface{}{"replicas": int64(2)}), parser)).To(Succeed())
+	// a field typo
+	g.Expect(validateMultusAdmissionControllerSchema(widget(map[string]interface{}{"replcas": int64(2)}), parser)).To(
+		MatchError(ContainSubstring("replcas")))
+	// a wrong type
+	g.Expect(validateMultusAdmissionControllerSchema(widget(map[string]interface{}{"replicas": "two"}), parser)).To(
+		MatchError(ContainSubstring("rendered Widget openshift-multus/test is invalid")))
+
+	// kinds without a schema are not validated
+	cm := &uns.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	g.Expect(validateMultusAdmissionControllerSchema(cm, parser)).To(Succeed())
+
+	// the schema is fetched from the cluster only when enabled
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.SchemaValidation = true
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+// TestRenderMultusAdmissionControllerCommandOverride tests the development only command override
+func TestRenderMultusAdmissionControllerCommandOverride(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.Command[0]).To(Equal("/bin/bash"))
+	g.Expect(container.Command[2]).To(ContainSubstring("exec /usr/bin/webhook"))
+
+	command := []string{"/usr/local/bin/diagnose.sh", "--wrap", "/usr/bin/webhook -port=6443", `say "hi"`}
+	bootstrapResult.MultusAdmissionController.CommandOverride = command
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("only allowed with development-mode")))
+
+	bootstrapResult.MultusAdmissionController.DevelopmentMode = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.Command).To(Equal(command))
+}
+
+// TestRenderMultusAdmissionControllerHealthEndpoints tests that liveness and readiness use distinct endpoints
+func TestRenderMultusAdmissionControllerHealthEndpoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.HealthProbes = true
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+
+	g.Expect(container.ReadinessProbe.HTTPGet).NotTo(BeNil())
+	g.Expect(container.LivenessProbe.HTTPGet).NotTo(BeNil())
+	g.Expect(container.ReadinessProbe.HTTPGet.Path).To(Equal("/readyz"))
+	g.Expect(container.LivenessProbe.HTTPGet.Path).To(Equal("/livez"))
+	g.Expect(container.LivenessProbe.HTTPGet.Path).NotTo(Equal(container.ReadinessProbe.HTTPGet.Path))
+	g.Expect(container.LivenessProbe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTPS))
+	g.Expect(container.LivenessProbe.FailureThreshold).To(BeNumerically(">", container.ReadinessProbe.FailureThreshold))
+}
+
+// TestRenderMultusAdmissionControllerRoleAggregation tests the aggregated ClusterRole
+func TestRenderMultusAdmissionControllerRoleAggregation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	findObject := func(objs []*uns.Unstructured, kind, name string) *uns.Unstructured {
+		for _, obj := range objs {
+			if obj.GetKind() == kind && obj.GetName() == name {
+				return obj
+			}
+		}
+		return nil
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findObject(objs, "ClusterRole", "multus-admission-controller-webhook")).NotTo(BeNil())
+	g.Expect(findObject(objs, "ClusterRole", "multus-admission-controller-webhook-aggregated")).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.RoleAggregation = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	obj := findObject(objs, "ClusterRole", "multus-admission-controller-webhook-aggregated")
+	g.Expect(obj).NotTo(BeNil())
+	role := &rbacv1.ClusterRole{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, role)).To(Succeed())
+	g.Expect(role.AggregationRule).NotTo(BeNil())
+	g.Expect(role.AggregationRule.ClusterRoleSelectors).To(HaveLen(1))
+	g.Expect(role.AggregationRule.ClusterRoleSelectors[0].MatchLabels).To(Equal(map[string]string{
+		"rbac.multus.openshift.io/aggregate-to-multus-admission-controller": "true",
+	}))
+
+	obj = findObject(objs, "ClusterRoleBinding", "multus-admission-controller-webhook-aggregated")
+	g.Expect(obj).NotTo(BeNil())
+	binding := &rbacv1.ClusterRoleBinding{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, binding)).To(Succeed())
+	g.Expect(binding.RoleRef.Name).To(Equal("multus-admission-controller-webhook-aggregated"))
+	g.Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: "multus-ac", Namespace: "openshift-multus"}))
+}
+
+// TestExplainMultusAdmissionControllerRender tests that the explained decisions match the objects
+// rendered with the same configuration
+func TestExplainMultusAdmissionControllerRender(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	explainAndRender := func(bootstrapResult *bootstrap.BootstrapResult, client cnoclient.Client, externalControlPlane bool) map[string]MultusAdmissionControllerRenderDecision {
+		decisions, err := ExplainMultusAdmissionControllerRender(multusAdmissionControllerTestConfig(), bootstrapResult, client)
+		g.Expect(err).NotTo(HaveOccurred())
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, externalControlPlane, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		rendered := map[string]bool{}
+		for _, obj := range objs {
+			rendered[obj.GetKind()+"/"+obj.GetName()] = true
+			if obj.GetKind() != "Template" {
+				continue
+			}
+			template := &templatev1.Template{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, template)).To(Succeed())
+			for _, raw := range template.Objects {
+				wrapped := &uns.Unstructured{}
+				g.Expect(wrapped.UnmarshalJSON(raw.Raw)).To(Succeed())
+				rendered[wrapped.GetKind()+"/"+wrapped.GetName()] = true
+			}
+		}
+		byObject := map[string]MultusAdmissionControllerRenderDecision{}
+		for _, decision := range decisions {
+			key := decision.Kind + "/" + decision.Name
+			g.Expect(rendered[key]).To(Equal(decision.Included), "%s: %s", key, decision.Reason)
+			g.Expect(decision.Reason).NotTo(BeEmpty())
+			byObject[key] = decision
+		}
+		return byObject
+	}
+
+	decisions := explainAndRender(fakeBootstrapResult(), cnofake.NewFakeClient(), false)
+	g.Expect(decisions).To(HaveKey("Deployment/multus-admission-controller"))
+	g.Expect(decisions["Namespace/openshift-multus"].Included).To(BeFalse())
+	g.Expect(decisions["ClusterRole/multus-admission-controller-webhook-writer"].Included).To(BeFalse())
+	g.Expect(decisions["ServiceMonitor/monitor-multus-admission-controller"].Included).To(BeTrue())
+	g.Expect(decisions["Role/prometheus-k8s"].Included).To(BeTrue())
+	g.Expect(decisions["PrometheusRule/prometheus-k8s-rules"].Included).To(BeTrue())
+	g.Expect(decisions["ValidatingWebhookConfiguration/multus.openshift.io"].Reason).To(Equal("webhook-registration is immediate"))
+	g.Expect(decisions["Template/multus-admission-controller"].Included).To(BeFalse())
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.SplitRBAC = true
+	bootstrapResult.MultusAdmissionController.DisableServiceMonitor = true
+	bootstrapResult.MultusAdmissionController.Autoscaling = true
+	decisions = explainAndRender(bootstrapResult, cnofake.NewFakeClient(), false)
+	g.Expect(decisions["ClusterRole/multus-admission-controller-webhook-writer"].Included).To(BeTrue())
+	g.Expect(decisions["ServiceMonitor/monitor-multus-admission-controller"].Reason).To(Equal("disable-service-monitor is set"))
+	g.Expect(decisions["HorizontalPodAutoscaler/multus-admission-controller"].Reason).To(ContainSubstring("are not served by the cluster"))
+
+	// the webhook configuration is held back until the controller endpoints are ready
+	bootstrapResult = fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.WebhookRegistration = multusAdmissionControllerWebhookRegistrationAfterReady
+	decisions = explainAndRender(bootstrapResult, cnofake.NewFakeClient(), false)
+	g.Expect(decisions["ValidatingWebhookConfiguration/multus.openshift.io"].Reason).To(ContainSubstring("rendered with the create-wait annotation"))
+
+	bootstrapResult.MultusAdmissionController.WebhookRegistration = ""
+	bootstrapResult.MultusAdmissionController.OutputFormat = multusAdmissionControllerOutputFormatTemplate
+	decisions = explainAndRender(bootstrapResult, cnofake.NewFakeClient(), false)
+	g.Expect(decisions["Template/multus-admission-controller"].Included).To(BeTrue())
+	g.Expect(decisions["Deployment/multus-admission-controller"].Included).To(BeTrue())
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.NamespacePolicy = multusAdmissionControllerNamespaceRender
+	decisions = explainAndRender(bootstrapResult, client, true)
+	g.Expect(decisions["Namespace/clusters-test"].Included).To(BeTrue())
+	g.Expect(decisions["Role/prometheus-k8s"].Included).To(BeFalse())
+
+	disabled := multusAdmissionControllerTestConfig()
+	disableMultiNetwork := true
+	disabled.DisableMultiNetwork = &disableMultiNetwork
+	explained, err := ExplainMultusAdmissionControllerRender(disabled, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(explained).To(Equal([]MultusAdmissionControllerRenderDecision{{
+		Kind: "Deployment", Name: "multus-admission-controller", Reason: "disableMultiNetwork is set",
+	}}))
+}
+
+// TestExplainMultusAdmissionControllerRenderSideEffects tests that explaining doesn't render: the
+// ignored namespaces and their gauge are left alone, and a configuration the render rejects is
+// explained all the same
+func TestExplainMultusAdmissionControllerRenderSideEffects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	client := cnofake.NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "openshift-multus",
+		Labels: map[string]string{"openshift.io/cluster-monitoring": "true"},
+	}})
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.WebhookMatchPolicy = "Fuzzy"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid webhook-match-policy")))
+
+	// a render would discover the ignored namespaces, which were never discovered
+	ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{}
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+	multusAdmissionControllerIgnoredNamespaces.Set(-1)
+
+	decisions, err := ExplainMultusAdmissionControllerRender(multusAdmissionControllerTestConfig(), bootstrapResult, client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(decisions).NotTo(BeEmpty())
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+	g.Expect(ignoredNamespacesUpdated.IsZero()).To(BeTrue())
+	value, err := testutil.GetGaugeMetricValue(multusAdmissionControllerIgnoredNamespaces)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal(-1.0))
+}
+
+// TestRenderMultusAdmissionControllerRejectionEvents tests the permission to emit Events on rejections
+func TestRenderMultusAdmissionControllerRejectionEvents(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderRejectionEvents := func(enabled bool) (*rbacv1.ClusterRole, *corev1.Container) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.RejectionEvents = enabled
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		role := &rbacv1.ClusterRole{}
+		for _, obj := range objs {
+			if obj.GetKind() == "ClusterRole" && obj.GetName() == "multus-admission-controller-webhook" {
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, role)).To(Succeed())
+			}
+		}
+		return role, getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	}
+	eventsRule := rbacv1.PolicyRule{
+		APIGroups: []string{"", "events.k8s.io"},
+		Resources: []string{"events"},
+		Verbs:     []string{"create", "patch"},
+	}
+
+	role, container := renderRejectionEvents(false)
+	g.Expect(role.Rules).NotTo(BeEmpty())
+	g.Expect(role.Rules).NotTo(ContainElement(eventsRule))
+	g.Expect(strings.Join(container.Command, " ")).NotTo(ContainSubstring("-emit-rejection-events"))
+
+	role, container = renderRejectionEvents(true)
+	g.Expect(role.Rules).To(ContainElement(eventsRule))
+	g.Expect(strings.Join(container.Command, " ")).To(ContainSubstring("-emit-rejection-events=true"))
+}
+
+// TestRenderMultusAdmissionControllerIgnoredNamespacesHash tests that ignored-namespaces-hash
+// annotates the webhook configuration with a hash that follows the ignore list
+func TestRenderMultusAdmissionControllerIgnoredNamespacesHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+
+	ignoredNamespacesHash := func(bootstrapResult *bootstrap.BootstrapResult, ignored string) (string, bool) {
+		ignoredNamespaces = ignored
+		ignoredNamespacesUpdated = time.Now()
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				hash, ok := obj.GetAnnotations()[multusAdmissionControllerIgnoredNamespacesHashAnnotation]
+				return hash, ok
+			}
+		}
+		t.Fatal("no ValidatingWebhookConfiguration rendered")
+		return "", false
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	_, ok := ignoredNamespacesHash(bootstrapResult, "openshift-test")
+	g.Expect(ok).To(BeFalse())
+
+	bootstrapResult.MultusAdmissionController.IgnoredNamespacesHash = true
+	hash, ok := ignoredNamespacesHash(bootstrapResult, "openshift-test")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(hash).NotTo(BeEmpty())
+	again, _ := ignoredNamespacesHash(bootstrapResult, "openshift-test")
+	g.Expect(again).To(Equal(hash))
+	changed, _ := ignoredNamespacesHash(bootstrapResult, "openshift-test,openshift-other")
+	g.Expect(changed).NotTo(Equal(hash))
+}
+
+// TestRenderMultusAdmissionControllerSplitRBAC tests that split-rbac renders the read and the
+// write permissions in separate roles and bindings
+func TestRenderMultusAdmissionControllerSplitRBAC(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderRoles := func(split bool) (map[string]*rbacv1.ClusterRole, map[string]*rbacv1.ClusterRoleBinding) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.SplitRBAC = split
+		bootstrapResult.MultusAdmissionController.RejectionEvents = true
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		roles := map[string]*rbacv1.ClusterRole{}
+		bindings := map[string]*rbacv1.ClusterRoleBinding{}
+		for _, obj := range objs {
+			switch obj.GetKind() {
+			case "ClusterRole":
+				role := &rbacv1.ClusterRole{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, role)).To(Succeed())
+				roles[role.Name] = role
+			case "ClusterRoleBinding":
+				binding := &rbacv1.ClusterRoleBinding{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, binding)).To(Succeed())
+				bindings[binding.Name] = binding
+			}
+		}
+		return roles, bindings
+	}
+
+	combined, _ := renderRoles(false)
+	g.Expect(combined).To(HaveLen(1))
+	g.Expect(combined).To(HaveKey("multus-admission-controller-webhook"))
+
+	roles, bindings := renderRoles(true)
+	g.Expect(roles).To(HaveLen(2))
+	reader := roles["multus-admission-controller-webhook"]
+	writer := roles["multus-admission-controller-webhook-writer"]
+	g.Expect(reader).NotTo(BeNil())
+	g.Expect(writer).NotTo(BeNil())
+	for _, rule := range reader.Rules {
+		g.Expect(rule.Verbs).To(ConsistOf("get", "list", "watch"))
+		g.Expect(writer.Rules).NotTo(ContainElement(rule))
+	}
+	for _, rule := range writer.Rules {
+		for _, verb := range rule.Verbs {
+			g.Expect(verb).NotTo(BeElementOf("get", "list", "watch"))
+		}
+	}
+	// both roles together grant what the combined one does
+	g.Expect(append(reader.Rules, writer.Rules...)).To(ConsistOf(combined["multus-admission-controller-webhook"].Rules))
+	for name := range roles {
+		g.Expect(bindings).To(HaveKey(name))
+		g.Expect(bindings[name].RoleRef.Name).To(Equal(name))
+	}
+}
+
+// TestRenderMultusAdmissionControllerAuditLog tests that the audit log volume is only rendered
+// with audit-log-dir
+func TestRenderMultusAdmissionControllerAuditLog(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderAuditLog := func(dir, hostPath string) (*corev1.PodSpec, error) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.AuditLogDir = dir
+		bootstrapResult.MultusAdmissionController.AuditLogHostPath = hostPath
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		return &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, nil
+	}
+	hasVolume := func(podSpec *corev1.PodSpec) bool {
+		for _, volume := range podSpec.Volumes {
+			if volume.Name == "audit-log" {
+				return true
+			}
+		}
+		return false
+	}
+
+	podSpec, err := renderAuditLog("", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hasVolume(podSpec)).To(BeFalse())
+	container := getContainer(g, podSpec, "multus-admission-controller")
+	g.Expect(container.VolumeMounts).NotTo(ContainElement(HaveField("Name", "audit-log")))
+	g.Expect(strings.Join(container.Command, " ")).NotTo(ContainSubstring("-audit-log-dir"))
+
+	podSpec, err = renderAuditLog("/var/log/multus-admission-controller/", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	sizeLimit := resource.MustParse("100Mi")
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name:         "audit-log",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}},
+	}))
+	container = getContainer(g, podSpec, "multus-admission-controller")
+	g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name:      "audit-log",
+		MountPath: "/var/log/multus-admission-controller",
+	}))
+	g.Expect(strings.Join(container.Command, " ")).To(ContainSubstring("-audit-log-dir=/var/log/multus-admission-controller "))
+
+	podSpec, err = renderAuditLog("/var/log/multus-admission-controller", "/var/log/multus")
+	g.Expect(err).NotTo(HaveOccurred())
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name: "audit-log",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+			Path: "/var/log/multus",
+			Type: &hostPathType,
+		}},
+	}))
+
+	for _, tc := range []struct {
+		dir, hostPath string
+		want          string
+	}{
+		{"var/log", "", "invalid audit-log-dir"},
+		{"/", "", "invalid audit-log-dir"},
+		{"/etc/webhook/audit", "", "overlaps the /etc/webhook mount"},
+		{"/var", "", "overlaps the /var/run/secrets mount"},
+		{"", "/var/log/multus", "audit-log-host-path requires audit-log-dir"},
+		{"/var/log/multus-admission-controller", "var/log", "invalid audit-log-host-path"},
+	} {
+		_, err = renderAuditLog(tc.dir, tc.hostPath)
+		g.Expect(err).To(MatchError(ContainSubstring(tc.want)), "audit-log-dir %q audit-log-host-path %q", tc.dir, tc.hostPath)
+	}
+}
+
+// TestRenderMultusAdmissionControllerWebhookWorkerCount tests the webhook worker count argument
+func TestRenderMultusAdmissionControllerWebhookWorkerCount(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderCommand := func(workers *int) (string, error) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.WebhookWorkerCount = workers
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return "", err
+		}
+		container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+		return strings.Join(container.Command, " "), nil
+	}
+	intPtr := func(i int) *int { return &i }
+
+	command, err := renderCommand(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(command).NotTo(ContainSubstring("-workers"))
+
+	command, err = renderCommand(intPtr(8))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(command).To(ContainSubstring("-workers=8 "))
+
+	for _, workers := range []int{0, -1, 65} {
+		_, err = renderCommand(intPtr(workers))
+		g.Expect(err).To(MatchError(ContainSubstring("invalid webhook-worker-count")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerEmptyClusterID tests a HostedControlPlane without a cluster ID
+func TestRenderMultusAdmissionControllerEmptyClusterID(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getEndpoint := func(objs []*uns.Unstructured) map[string]interface{} {
+		for _, obj := range objs {
+			if obj.GetKind() == "ServiceMonitor" {
+				endpoints, _, err := uns.NestedSlice(obj.Object, "spec", "endpoints")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(endpoints).To(HaveLen(1))
+				return endpoints[0].(map[string]interface{})
+			}
+		}
+		return nil
+	}
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	endpoint := getEndpoint(objs)
+	g.Expect(endpoint).To(HaveKey("relabelings"))
+	g.Expect(endpoint).To(HaveKey("metricRelabelings"))
+
+	bootstrapResult.Infra.HostedControlPlane.Spec.ClusterID = ""
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	endpoint = getEndpoint(objs)
+	g.Expect(endpoint).NotTo(BeNil())
+	g.Expect(endpoint).NotTo(HaveKey("relabelings"))
+	g.Expect(endpoint).NotTo(HaveKey("metricRelabelings"))
+
+	bootstrapResult.MultusAdmissionController.RequireClusterID = true
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("has no cluster ID")))
+}
+
+// TestRenderMultusAdmissionControllerWebhookUpdateStrategy tests the webhook rules hash
+func TestRenderMultusAdmissionControllerWebhookUpdateStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderHash := func(strategy string, extraRules []admissionregistrationv1.RuleWithOperations, sideEffects admissionregistrationv1.SideEffectClass) (string, bool) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.WebhookUpdateStrategy = strategy
+		bootstrapResult.MultusAdmissionController.ExtraWebhookRules = extraRules
+		bootstrapResult.MultusAdmissionController.SideEffects = sideEffects
+		client := cnofake.NewFakeClient()
+		client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods", Namespaced: true, Kind: "Pod"}},
+		}}
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				hash, ok := obj.GetAnnotations()[names.ReplaceOnChangeAnnotation]
+				return hash, ok
+			}
+		}
+		g.Expect(objs).To(ContainElement(HaveKubernetesID("ValidatingWebhookConfiguration", "", "multus.openshift.io")))
+		return "", false
+	}
+	podRules := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"pods"},
+		},
+	}}
+
+	for _, strategy := range []string{"", "Apply"} {
+		_, ok := renderHash(strategy, nil, "")
+		g.Expect(ok).To(BeFalse())
+	}
+
+	hash, ok := renderHash("ReplaceOnRulesChange", nil, "")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(hash).NotTo(BeEmpty())
+
+	// a change outside of the rules is merged
+	unrelated, _ := renderHash("ReplaceOnRulesChange", nil, admissionregistrationv1.SideEffectClassNone)
+	g.Expect(unrelated).To(Equal(hash))
+
+	// a rules change replaces the webhook configuration
+	changed, _ := renderHash("ReplaceOnRulesChange", podRules, "")
+	g.Expect(changed).NotTo(Equal(hash))
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.WebhookUpdateStrategy = "Recreate"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid webhook-update-strategy")))
+}
+
+// TestRenderMultusAdmissionControllerBackupAnnotations tests annotating the selected objects for backup tooling
+func TestRenderMultusAdmissionControllerBackupAnnotations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.BackupAnnotations = []bootstrap.MultusAdmissionControllerObjectAnnotations{
+		{
+			Kind:        "Deployment",
+			Annotations: map[string]string{"backup.velero.io/backup-volumes-excludes": "webhook-certs"},
+		},
+		{
+			Kind: "ValidatingWebhookConfiguration",
+			Name: "multus.openshift.io",
+			Annotations: map[string]string{
+				"velero.io/restore-priority":                "last",
+				"service.beta.openshift.io/inject-cabundle": "false",
+			},
+		},
+	}
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		anno := obj.GetAnnotations()
+		switch obj.GetKind() {
+		case "Deployment":
+			g.Expect(anno).To(HaveKeyWithValue("backup.velero.io/backup-volumes-excludes", "webhook-certs"))
+			g.Expect(anno).NotTo(HaveKey("velero.io/restore-priority"))
+		case "ValidatingWebhookConfiguration":
+			g.Expect(anno).To(HaveKeyWithValue("velero.io/restore-priority", "last"))
+			// the render's annotations are kept
+			g.Expect(anno).To(HaveKeyWithValue("service.beta.openshift.io/inject-cabundle", "true"))
+			g.Expect(anno).NotTo(HaveKey("backup.velero.io/backup-volumes-excludes"))
+		default:
+			g.Expect(anno).NotTo(HaveKey("backup.velero.io/backup-volumes-excludes"), "%s %s", obj.GetKind(), obj.GetName())
+			g.Expect(anno).NotTo(HaveKey("velero.io/restore-priority"), "%s %s", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	bootstrapResult.MultusAdmissionController.BackupAnnotations = []bootstrap.MultusAdmissionControllerObjectAnnotations{{
+		Kind:        "Deployment",
+		Annotations: map[string]string{names.ReconcileIntervalAnnotation: "1h"},
+	}}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("managed by the operator")))
+
+	bootstrapResult.MultusAdmissionController.BackupAnnotations = []bootstrap.MultusAdmissionControllerObjectAnnotations{{
+		Annotations: map[string]string{"velero.io/restore-priority": "last"},
+	}}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("no kind")))
+}
+
+// TestRenderMultusAdmissionControllerRunAsUserSource tests running the pod with a UID of the namespace range
+func TestRenderMultusAdmissionControllerRunAsUserSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	uidRangeNamespace := func(name, uidRange string) *corev1.Namespace {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if uidRange != "" {
+			namespace.Annotations = map[string]string{"openshift.io/sa.scc.uid-range": uidRange}
+		}
+		return namespace
+	}
+	runAsUser := func(objs []*uns.Unstructured) *int64 {
+		securityContext := getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.SecurityContext
+		g.Expect(securityContext).NotTo(BeNil())
+		return securityContext.RunAsUser
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	client := cnofake.NewFakeClient(uidRangeNamespace("openshift-multus", "1000680000/10000"))
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runAsUser(objs)).To(HaveValue(BeEquivalentTo(65534)))
+
+	bootstrapResult.MultusAdmissionController.RunAsUserSource = "NamespaceRange"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runAsUser(objs)).To(HaveValue(BeEquivalentTo(1000680000)))
+
+	// in HyperShift the range is the management cluster namespace's
+	bootstrapResult, _ = fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.RunAsUserSource = "NamespaceRange"
+	client = cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			uidRangeNamespace("clusters-test", "1000720000/10000"),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: "clusters-test"},
+				Data:       map[string]string{"service-ca.crt": "test-ca"},
+			},
+		},
+	})
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runAsUser(objs)).To(HaveValue(BeEquivalentTo(1000720000)))
+
+	bootstrapResult = fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.RunAsUserSource = "NamespaceRange"
+	for uidRange, expected := range map[string]string{
+		"":               "has no openshift.io/sa.scc.uid-range annotation",
+		"1000680000":     "invalid openshift.io/sa.scc.uid-range annotation",
+		"0/10000":        "non-root first UID",
+		"1000680000/0":   "positive size",
+		"1000680000/abc": "positive size",
+	} {
+		client := cnofake.NewFakeClient(uidRangeNamespace("openshift-multus", uidRange))
+		_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).To(MatchError(ContainSubstring(expected)), uidRange)
+	}
+
+	bootstrapResult.MultusAdmissionController.RunAsUserSource = "Random"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid run-as-user-source")))
+}
+
+// TestRenderMultusAdmissionControllerYAML tests that identical renders serialize to identical YAML
+func TestRenderMultusAdmissionControllerYAML(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderYAML := func() []byte {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		out, err := render.RenderYAML(objs)
+		g.Expect(err).NotTo(HaveOccurred())
+		return out
+	}
+	first := renderYAML()
+	g.Expect(string(first)).To(HavePrefix("---\n"))
+	g.Expect(renderYAML()).To(Equal(first))
+}
+
+// TestGetMultusAdmissionControllerOverlappingWebhooks tests finding other webhooks validating NADs
+func TestGetMultusAdmissionControllerOverlappingWebhooks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	webhookConfig := func(name string, rules ...admissionregistrationv1.RuleWithOperations) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name:  "validate." + name,
+				Rules: rules,
+			}},
+		}
+	}
+	rule := func(operation admissionregistrationv1.OperationType, group, version, resource string) admissionregistrationv1.RuleWithOperations {
+		return admissionregistrationv1.RuleWithOperations{
+			Operations: []admissionregistrationv1.OperationType{operation},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{group},
+				APIVersions: []string{version},
+				Resources:   []string{resource},
+			},
+		}
+	}
+	clusterScope := rule(admissionregistrationv1.OperationAll, "*", "*", "*")
+	scope := admissionregistrationv1.ClusterScope
+	clusterScope.Scope = &scope
+
+	client := cnofake.NewFakeClient(
+		// the admission controller's own
+		webhookConfig("multus.openshift.io", rule(admissionregistrationv1.Create, "k8s.cni.cncf.io", "v1", "network-attachment-definitions")),
+		webhookConfig("other-nad-validator", rule(admissionregistrationv1.Update, "k8s.cni.cncf.io", "v1", "network-attachment-definitions")),
+		webhookConfig("wildcard", rule(admissionregistrationv1.OperationAll, "*", "*", "*/*")),
+		webhookConfig("pods", rule(admissionregistrationv1.Create, "", "v1", "pods")),
+		webhookConfig("deletes", rule(admissionregistrationv1.Delete, "k8s.cni.cncf.io", "v1", "network-attachment-definitions")),
+		webhookConfig("subresource", rule(admissionregistrationv1.Update, "k8s.cni.cncf.io", "v1", "network-attachment-definitions/status")),
+		webhookConfig("cluster-scoped", clusterScope),
+	)
+	overlapping, err := getMultusAdmissionControllerOverlappingWebhooks(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(overlapping).To(Equal([]string{
+		"other-nad-validator/validate.other-nad-validator",
+		"wildcard/validate.wildcard",
+	}))
+
+	overlapping, err = getMultusAdmissionControllerOverlappingWebhooks(cnofake.NewFakeClient())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(overlapping).To(BeEmpty())
+
+}
+
+// TestReportMultusAdmissionControllerOverlappingWebhooks tests that the overlapping webhooks are
+// only reported when they change
+func TestReportMultusAdmissionControllerOverlappingWebhooks(t *testing.T) {
+	g := NewGomegaWithT(t)
+	multusAdmissionControllerReportedOverlappingWebhooks = ""
+	t.Cleanup(func() { multusAdmissionControllerReportedOverlappingWebhooks = "" })
+
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks(nil)).To(BeFalse())
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks([]string{"a/validate.a"})).To(BeTrue())
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks([]string{"a/validate.a"})).To(BeFalse())
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks([]string{"a/validate.a", "b/validate.b"})).To(BeTrue())
+	// as is the overlap going away
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks([]string{})).To(BeTrue())
+	g.Expect(reportMultusAdmissionControllerOverlappingWebhooks(nil)).To(BeFalse())
+}
+
+// TestRenderMultusAdmissionControllerExtraEnv tests the environment variables added to the webhook container
+func TestRenderMultusAdmissionControllerExtraEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderEnv := func(extraEnv []corev1.EnvVar) ([]corev1.EnvVar, error) {
+		bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+		bootstrapResult.MultusAdmissionController.ExtraEnv = extraEnv
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		return getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller").Env, nil
+	}
+
+	env, err := renderEnv(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(env).To(HaveLen(1))
+
+	extraEnv := []corev1.EnvVar{
+		{Name: "DEBUG_NAD_VALIDATION", Value: "true"},
+		{Name: "feature.gate", Value: "x"},
+		{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+	}
+	env, err = renderEnv(extraEnv)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(env[0].Name).To(Equal("KUBECONFIG"))
+	g.Expect(env[1:]).To(Equal(extraEnv))
+
+	// outside of HyperShift, without extra-env, no env is rendered at all
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller").Env).To(BeEmpty())
+
+	for _, tc := range []struct {
+		env      []corev1.EnvVar
+		expected string
+	}{
+		{[]corev1.EnvVar{{Name: "KUBECONFIG", Value: "/tmp/kubeconfig"}}, "reserved"},
+		{[]corev1.EnvVar{{Name: "SSL_CERT_FILE", Value: "/tmp/ca.crt"}}, "reserved"},
+		{[]corev1.EnvVar{{Name: "1DEBUG", Value: "true"}}, "invalid extra-env name"},
+		{[]corev1.EnvVar{{Name: "", Value: "true"}}, "invalid extra-env name"},
+		{[]corev1.EnvVar{{Name: "DEBUG", Value: "true"}, {Name: "DEBUG", Value: "false"}}, "set more than once"},
+	} {
+		_, err = renderEnv(tc.env)
+		g.Expect(err).To(MatchError(ContainSubstring(tc.expected)), "%v", tc.env)
+	}
+}
+
+// TestRenderMultusAdmissionControllerNADVersions tests the NetworkAttachmentDefinition versions of the webhook rules
+func TestRenderMultusAdmissionControllerNADVersions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	nadVersions := func(matchPolicy admissionregistrationv1.MatchPolicyType, versions ...string) *admissionregistrationv1.ValidatingWebhook {
+		client := cnofake.NewFakeClient()
+		resources := []*metav1.APIResourceList{{
+			// another resource of the group doesn't make its version served
+			GroupVersion: "k8s.cni.cncf.io/v1alpha1",
+			APIResources: []metav1.APIResource{{Name: "multi-networkpolicies", Namespaced: true, Kind: "MultiNetworkPolicy"}},
+		}}
+		for _, version := range versions {
+			resources = append(resources, &metav1.APIResourceList{
+				GroupVersion: "k8s.cni.cncf.io/" + version,
+				APIResources: []metav1.APIResource{{Name: "network-attachment-definitions", Namespaced: true, Kind: "NetworkAttachmentDefinition"}},
+			})
+		}
+		client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = resources
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.WebhookMatchPolicy = matchPolicy
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhookConfig)).To(Succeed())
+				return &webhookConfig.Webhooks[0]
+			}
+		}
+		return nil
+	}
+
+	// v1 until the CRD is served
+	webhook := nadVersions("")
+	g.Expect(webhook).NotTo(BeNil())
+	g.Expect(*webhook.MatchPolicy).To(Equal(admissionregistrationv1.Equivalent))
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v1"}))
+
+	webhook = nadVersions("", "v1")
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v1"}))
+
+	// the newest served version, which the older ones are matched to, with stable versions
+	// ahead of the prereleases like in Kubernetes
+	webhook = nadVersions("", "v1beta1", "v2beta1")
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v2beta1"}))
+	webhook = nadVersions("", "v1", "v2beta1", "v1beta1")
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v1"}))
+	webhook = nadVersions("", "v1", "v2", "v2beta1")
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v2"}))
+
+	// all the served versions
+	webhook = nadVersions(admissionregistrationv1.Exact, "v1", "v2", "v2beta1")
+	g.Expect(*webhook.MatchPolicy).To(Equal(admissionregistrationv1.Exact))
+	g.Expect(webhook.Rules[0].APIVersions).To(Equal([]string{"v2", "v1", "v2beta1"}))
+
+	// the default version when the served versions can't be discovered: the fake discovery fails
+	// to list the groups with an invalid group version
+	client := cnofake.NewFakeClient()
+	client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "k8s.cni.cncf.io/v2",
+			APIResources: []metav1.APIResource{{Name: "network-attachment-definitions", Namespaced: true, Kind: "NetworkAttachmentDefinition"}},
+		},
+		{GroupVersion: "k8s.cni.cncf.io/v2/invalid"},
+	}
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	for _, obj := range objs {
+		if obj.GetKind() == "ValidatingWebhookConfiguration" {
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhookConfig)).To(Succeed())
+		}
+	}
+	g.Expect(webhookConfig.Webhooks).NotTo(BeEmpty())
+	g.Expect(webhookConfig.Webhooks[0].Rules[0].APIVersions).To(Equal([]string{"v1"}))
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.WebhookMatchPolicy = "Fuzzy"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid webhook-match-policy")))
+}
+
+// TestValidateMultusAdmissionControllerTopology tests the combinations of the external control plane
+// flag, HyperShift and the control plane topology
+func TestValidateMultusAdmissionControllerTopology(t *testing.T) {
+	tests := []struct {
+		name                 string
+		externalControlPlane bool
+		hyperShift           bool
+		topology             configv1.TopologyMode
+		wantErr              string
+	}{
+		{
+			name:     "self-hosted, highly available",
+			topology: configv1.HighlyAvailableTopologyMode,
+		},
+		{
+			name:     "self-hosted, single replica",
+			topology: configv1.SingleReplicaTopologyMode,
+		},
+		{
+			name: "self-hosted, unknown topology",
+		},
+		{
+			name:                 "HyperShift, external topology",
+			externalControlPlane: true,
+			hyperShift:           true,
+			topology:             configv1.ExternalTopologyMode,
+		},
+		{
+			name:                 "HyperShift, unknown topology",
+			externalControlPlane: true,
+			hyperShift:           true,
+		},
+		{
+			name:       "HyperShift without external control plane",
+			hyperShift: true,
+			wantErr:    "HyperShift is enabled but the control plane is not external",
+		},
+		{
+			name:                 "external control plane without HyperShift",
+			externalControlPlane: true,
+		},
+		{
+			name:                 "external control plane without HyperShift, external topology",
+			externalControlPlane: true,
+			topology:             configv1.ExternalTopologyMode,
+		},
+		{
+			name:                 "HyperShift, highly available topology",
+			externalControlPlane: true,
+			hyperShift:           true,
+			topology:             configv1.HighlyAvailableTopologyMode,
+			wantErr:              "HyperShift is enabled but the control plane is not external",
+		},
+		{
+			name:     "internal control plane, external topology",
+			topology: configv1.ExternalTopologyMode,
+		},
+		{
+			name:       "HyperShift, internal control plane, external topology",
+			hyperShift: true,
+			topology:   configv1.ExternalTopologyMode,
+			wantErr:    "HyperShift is enabled but the control plane is not external",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			bootstrapResult := fakeBootstrapResult()
+			bootstrapResult.Infra.ControlPlaneTopology = tt.topology
+			bootstrapResult.MultusAdmissionController.HyperShiftConfig = &bootstrap.MultusAdmissionControllerHyperShiftBootstrapResult{
+				Enabled: tt.hyperShift,
+			}
+			err := validateMultusAdmissionControllerTopology(tt.externalControlPlane, bootstrapResult)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+// TestRenderMultusAdmissionControllerExternalTopology tests that a cluster with an external control
+// plane and no HyperShift, like a managed cluster running the operator in-cluster, renders
+func TestRenderMultusAdmissionControllerExternalTopology(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.Infra.ControlPlaneTopology = configv1.ExternalTopologyMode
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
+}
+
+// TestRenderMultusAdmissionControllerRevisionHistoryLimit tests the Deployment revisionHistoryLimit
+func TestRenderMultusAdmissionControllerRevisionHistoryLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	intPtr := func(i int) *int { return &i }
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*getMultusAdmissionControllerDeployment(g, objs).Spec.RevisionHistoryLimit).To(Equal(int32(2)))
+
+	for _, limit := range []int{0, 5} {
+		bootstrapResult.MultusAdmissionController.RevisionHistoryLimit = intPtr(limit)
+		objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(*getMultusAdmissionControllerDeployment(g, objs).Spec.RevisionHistoryLimit).To(Equal(int32(limit)))
+	}
+
+	bootstrapResult.MultusAdmissionController.RevisionHistoryLimit = intPtr(-1)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid revision-history-limit")))
+}
+
+// TestRenderMultusAdmissionControllerTokenMinterRequestTimeout tests the HyperShift token minter
+// API request timeout
+func TestRenderMultusAdmissionControllerTokenMinterRequestTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+	durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+	renderMinterArgs := func(timeout *time.Duration) ([]string, error) {
+		bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+		bootstrapResult.MultusAdmissionController.TokenMinterRequestTimeout = timeout
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		if err != nil {
+			return nil, err
+		}
+		return getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "hosted-cluster-token").Args, nil
+	}
+
+	args, err := renderMinterArgs(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(args).To(ContainElement("--request-timeout=30s"))
+
+	args, err = renderMinterArgs(durationPtr(90 * time.Second))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(args).To(ContainElement("--request-timeout=1m30s"))
+
+	for _, timeout := range []time.Duration{0, 10 * time.Minute} {
+		_, err = renderMinterArgs(durationPtr(timeout))
+		g.Expect(err).To(MatchError(ContainSubstring("invalid token-minter-request-timeout")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerTokenMinterAPIServer tests selecting the APIServer the token minter uses
+func TestRenderMultusAdmissionControllerTokenMinterAPIServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getKubernetesService := func(objs []*uns.Unstructured) (string, string) {
+		container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "hosted-cluster-kubecfg-setup")
+		var host, port string
+		for _, env := range container.Env {
+			switch env.Name {
+			case "KUBERNETES_SERVICE_HOST":
+				host = env.Value
+			case "KUBERNETES_SERVICE_PORT":
+				port = env.Value
+			}
+		}
+		return host, port
+	}
+
+	// local
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.Infra.APIServers[bootstrap.APIServerDefault] = bootstrap.APIServer{
+		Host: "api.testing.example.com",
+		Port: "443",
+	}
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	host, port := getKubernetesService(objs)
+	g.Expect(host).To(Equal("testing.local"))
+	g.Expect(port).To(Equal("6443"))
+
+	// external
+	bootstrapResult.MultusAdmissionController.TokenMinterAPIServer = bootstrap.APIServerDefault
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	host, port = getKubernetesService(objs)
+	g.Expect(host).To(Equal("api.testing.example.com"))
+	g.Expect(port).To(Equal("443"))
+
+	bootstrapResult.MultusAdmissionController.TokenMinterAPIServer = "nonexistent"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("missing Infra.APIServers[nonexistent], selected by token-minter-api-server")))
+}
+
+// TestRenderMultusAdmissionControllerWebhookConfiguration tests rendering only the webhook
+// configuration, and that it is the one of the full render
+func TestRenderMultusAdmissionControllerWebhookConfiguration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShiftWithCA("test-ca")
+	obj, err := RenderMultusAdmissionControllerWebhookConfiguration(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(obj).NotTo(BeNil())
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, config)).To(Succeed())
+	g.Expect(config.Name).To(Equal(names.MULTUS_VALIDATING_WEBHOOK))
+	g.Expect(config.Webhooks).To(HaveLen(1))
+	g.Expect(config.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte("test-ca")))
+	g.Expect(config.Webhooks[0].Rules).To(ConsistOf(admissionregistrationv1.RuleWithOperations{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"k8s.cni.cncf.io"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"network-attachment-definitions"},
+		},
+	}))
+
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(Equal(obj)))
+
+	conf := multusAdmissionControllerTestConfig()
+	disableMultiNetwork := true
+	conf.DisableMultiNetwork = &disableMultiNetwork
+	obj, err = RenderMultusAdmissionControllerWebhookConfiguration(conf, manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(obj).To(BeNil())
+}
+
+// TestMultusAdmissionControllerCABundleFingerprint tests that the caBundle fingerprint is stable
+// for a CA, and tells different CAs apart
+func TestMultusAdmissionControllerCABundleFingerprint(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fingerprint := func(ca string) string {
+		bootstrapResult, client := fakeMultusAdmissionControllerHyperShiftWithCA(ca)
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		fingerprint, err := MultusAdmissionControllerCABundleFingerprint(objs)
+		g.Expect(err).NotTo(HaveOccurred())
+		return fingerprint
+	}
+
+	first := fingerprint("test-ca-1")
+	digest := sha256.Sum256([]byte("test-ca-1"))
+	g.Expect(first).To(Equal(hex.EncodeToString(digest[:])[:16]))
+	g.Expect(fingerprint("test-ca-1")).To(Equal(first))
+	g.Expect(fingerprint("test-ca-2")).NotTo(Equal(first))
+
+	// the service CA injects the caBundle outside of HyperShift
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(MultusAdmissionControllerCABundleFingerprint(objs)).To(BeEmpty())
+}
+
+// TestMultusAdmissionControllerRenderSummary tests the key decisions logged after a render
+func TestMultusAdmissionControllerRenderSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	summaryMap := func(summary []interface{}) map[string]interface{} {
+		g.Expect(len(summary) % 2).To(Equal(0))
+		m := map[string]interface{}{}
+		for i := 0; i < len(summary); i += 2 {
+			m[summary[i].(string)] = summary[i+1]
+		}
+		return m
+	}
+
+	data := render.MakeRenderData()
+	data.Data["Replicas"] = 2
+	data.Data["FailurePolicy"] = "Fail"
+	data.Data["HyperShiftEnabled"] = false
+	data.Data["AdmissionControllerNamespace"] = "openshift-multus"
+	data.Data["IgnoredNamespace"] = "openshift-etcd,openshift-multus"
+	data.Data["RHOBSMonitoring"] = ""
+	data.Data["MultusAdmissionControllerImage"] = "quay.io/openshift/multus-admission-controller:test"
+	summary := summaryMap(multusAdmissionControllerRenderSummary(&data, 10))
+	g.Expect(summary).To(HaveKeyWithValue("objects", 10))
+	g.Expect(summary).To(HaveKeyWithValue("replicas", 2))
+	g.Expect(summary).To(HaveKeyWithValue("failurePolicy", "Fail"))
+	g.Expect(summary).To(HaveKeyWithValue("hyperShift", false))
+	g.Expect(summary).To(HaveKeyWithValue("ignoredNamespaces", 2))
+	g.Expect(summary).To(HaveKeyWithValue("monitoring", "cluster-monitoring"))
+	g.Expect(summary).To(HaveKeyWithValue("image", "quay.io/openshift/multus-admission-controller:test"))
+	g.Expect(summary).NotTo(HaveKey("tokenMinterImage"))
+
+	data.Data["HyperShiftEnabled"] = true
+	data.Data["IgnoredNamespace"] = ""
+	data.Data["RHOBSMonitoring"] = "1"
+	data.Data["TokenMinterImage"] = "quay.io/openshift/token-minter:test"
+	summary = summaryMap(multusAdmissionControllerRenderSummary(&data, 10))
+	g.Expect(summary).To(HaveKeyWithValue("ignoredNamespaces", 0))
+	g.Expect(summary).To(HaveKeyWithValue("monitoring", "rhobs"))
+	g.Expect(summary).To(HaveKeyWithValue("tokenMinterImage", "quay.io/openshift/token-minter:test"))
+}
+
+// TestUpdateIgnoredNamespaces tests that the discovered ignored namespaces outlive discovery failures
+// for the grace period
+func TestUpdateIgnoredNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{}
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+
+	client := cnofake.NewFakeClient(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openshift-test",
+			Labels: map[string]string{
+				"openshift.io/cluster-monitoring": "true",
+			},
+		},
+	})
+	failing := cnofake.NewFakeClient()
+	failing.Default().Kubernetes().(*kubefake.Clientset).PrependReactor("list", "namespaces",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("api unavailable")
+		})
+	empty := cnofake.NewFakeClient()
+	refreshInterval := 10 * time.Minute
+	gracePeriod := 30 * time.Minute
+	discovered := time.Now()
+
+	// a failed discovery has nothing to keep, and is retried
+	updateIgnoredNamespaces(failing, 0, gracePeriod, discovered.Add(-time.Minute))
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+	g.Expect(ignoredNamespacesUpdated.IsZero()).To(BeTrue())
+
+	updateIgnoredNamespaces(client, refreshInterval, gracePeriod, discovered)
+	g.Expect(ignoredNamespaces).To(Equal("openshift-test"))
+
+	// not due for a refresh
+	updateIgnoredNamespaces(failing, refreshInterval, gracePeriod, discovered.Add(time.Minute))
+	g.Expect(ignoredNamespaces).To(Equal("openshift-test"))
+
+	// failed refresh within the grace period keeps the discovered namespaces
+	updateIgnoredNamespaces(failing, refreshInterval, gracePeriod, discovered.Add(20*time.Minute))
+	g.Expect(ignoredNamespaces).To(Equal("openshift-test"))
+	g.Expect(ignoredNamespacesUpdated).To(Equal(discovered))
+
+	// failed refresh after the grace period falls back to none
+	updateIgnoredNamespaces(failing, refreshInterval, gracePeriod, discovered.Add(31*time.Minute))
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+
+	// a successful refresh restores them
+	updateIgnoredNamespaces(client, refreshInterval, gracePeriod, discovered.Add(32*time.Minute))
+	g.Expect(ignoredNamespaces).To(Equal("openshift-test"))
+	g.Expect(ignoredNamespacesUpdated).To(Equal(discovered.Add(32 * time.Minute)))
+
+	// without a grace period, any failed refresh falls back to none
+	updateIgnoredNamespaces(failing, refreshInterval, 0, discovered.Add(42*time.Minute))
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+
+	// without a refresh interval, a successful discovery is never repeated, even with no namespaces
+	ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{}
+	updateIgnoredNamespaces(empty, 0, gracePeriod, discovered)
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+	g.Expect(ignoredNamespacesUpdated).To(Equal(discovered))
+	updateIgnoredNamespaces(client, 0, gracePeriod, discovered.Add(24*time.Hour))
+	g.Expect(ignoredNamespaces).To(BeEmpty())
+	g.Expect(ignoredNamespacesUpdated).To(Equal(discovered))
+}
+
+// TestRenderMultusAdmissionControllerPodGroups tests the pod fsGroup and supplementalGroups
+func TestRenderMultusAdmissionControllerPodGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	intPtr := func(i int) *int { return &i }
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec := &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(podSpec.SecurityContext.FSGroup).To(BeNil())
+	g.Expect(podSpec.SecurityContext.SupplementalGroups).To(BeEmpty())
+	defaultContainerSecurityContext := getContainer(g, podSpec, "multus-admission-controller").SecurityContext
+
+	bootstrapResult.MultusAdmissionController.FSGroup = intPtr(0)
+	bootstrapResult.MultusAdmissionController.SupplementalGroups = []int{1000, 2000}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(*podSpec.SecurityContext.FSGroup).To(Equal(int64(0)))
+	g.Expect(podSpec.SecurityContext.SupplementalGroups).To(Equal([]int64{1000, 2000}))
+	g.Expect(*podSpec.SecurityContext.RunAsNonRoot).To(BeTrue())
+	// the groups only change the pod volume ownership, the containers security context is untouched
+	container := getContainer(g, podSpec, "multus-admission-controller")
+	g.Expect(container.SecurityContext).To(Equal(defaultContainerSecurityContext))
+	g.Expect(container.SecurityContext.ReadOnlyRootFilesystem).To(BeNil())
+
+	hyperShiftBootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	hyperShiftBootstrapResult.MultusAdmissionController.FSGroup = intPtr(1001)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, hyperShiftBootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	podSpec = &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+	g.Expect(*podSpec.SecurityContext.FSGroup).To(Equal(int64(1001)))
+	g.Expect(podSpec.SecurityContext.RunAsUser).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.FSGroup = intPtr(-1)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid fs-group")))
+
+	bootstrapResult.MultusAdmissionController.FSGroup = nil
+	bootstrapResult.MultusAdmissionController.SupplementalGroups = []int{math.MaxInt32 + 1}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid supplemental-groups")))
+}
+
+// TestValidateMultusAdmissionControllerPorts tests that port collisions are named precisely
+func TestValidateMultusAdmissionControllerPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(validateMultusAdmissionControllerPorts(6443, 9091, 8443)).To(Succeed())
+	g.Expect(validateMultusAdmissionControllerPorts(6443, 6443, 8443)).To(MatchError(
+		"invalid ports: the webhook port and the kube-rbac-proxy upstream port are both 6443"))
+	g.Expect(validateMultusAdmissionControllerPorts(6443, 9091, 9091)).To(MatchError(
+		"invalid ports: the kube-rbac-proxy upstream port and the metrics port (metrics-port) are both 9091"))
+	g.Expect(validateMultusAdmissionControllerPorts(8443, 9091, 8443)).To(MatchError(
+		"invalid ports: the webhook port and the metrics port (metrics-port) are both 8443"))
+
+	metricsPort := 9091
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.MetricsPort = &metricsPort
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("the kube-rbac-proxy upstream port and the metrics port (metrics-port) are both 9091")))
+}
+
+// TestRenderMultusAdmissionControllerImageDigest tests rendering the admission controller image by tag and by digest
+func TestRenderMultusAdmissionControllerImageDigest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	digest := "sha256:" + strings.Repeat("0123456789abcdef", 4)
+	for _, tc := range []struct {
+		image string
+		want  string
+	}{
+		{"quay.io/openshift/multus-admission-controller:4.16", "quay.io/openshift/multus-admission-controller@" + digest},
+		{"registry.local:5000/openshift/multus-admission-controller:4.16", "registry.local:5000/openshift/multus-admission-controller@" + digest},
+		{"registry.local:5000/openshift/multus-admission-controller", "registry.local:5000/openshift/multus-admission-controller@" + digest},
+	} {
+		g.Expect(resolveImageDigest(tc.image, map[string]string{tc.image: digest})).To(Equal(tc.want))
+	}
+	// no known digest, or already pinned
+	g.Expect(resolveImageDigest("quay.io/openshift/multus-admission-controller:4.16", nil)).To(
+		Equal("quay.io/openshift/multus-admission-controller:4.16"))
+	pinned := "quay.io/openshift/multus-admission-controller@sha256:" + strings.Repeat("f", 64)
+	g.Expect(resolveImageDigest(pinned, map[string]string{pinned: digest})).To(Equal(pinned))
+
+	image := "quay.io/openshift/multus-admission-controller:4.16"
+	t.Setenv("MULTUS_ADMISSION_CONTROLLER_IMAGE", image)
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.Image).To(Equal(image))
+
+	bootstrapResult.MultusAdmissionController.ImageDigests = map[string]string{image: digest}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.Image).To(Equal("quay.io/openshift/multus-admission-controller@" + digest))
+
+	bootstrapResult.MultusAdmissionController.ImageDigests = map[string]string{image: "sha256:1234"}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid image-digests digest")))
+}
+
+// TestNormalizeImageReference tests trimming and canonicalizing the image references
+func TestNormalizeImageReference(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, tc := range []struct {
+		image string
+		want  string
+	}{
+		{"", ""},
+		{"quay.io/openshift/multus-admission-controller:4.16", "quay.io/openshift/multus-admission-controller:4.16"},
+		{" quay.io/openshift/multus-admission-controller:4.16\n", "quay.io/openshift/multus-admission-controller:4.16"},
+		{"Quay.IO/openshift/multus-admission-controller:4.16", "quay.io/openshift/multus-admission-controller:4.16"},
+		{"Registry.Local:5000/openshift/multus-admission-controller", "registry.local:5000/openshift/multus-admission-controller"},
+		{"localhost/multus-admission-controller:Test", "localhost/multus-admission-controller:Test"},
+		{"multus-admission-controller:4.16", "multus-admission-controller:4.16"},
+	} {
+		image, err := normalizeImageReference(tc.image)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(image).To(Equal(tc.want), "image %q", tc.image)
+	}
+
+	for _, image := range []string{"quay.io/", "quay.io/:4.16", ":4.16", "@sha256:1234", "quay.io//multus", "quay.io/openshift/multus admission"} {
+		_, err := normalizeImageReference(image)
+		g.Expect(err).To(HaveOccurred(), "image %q", image)
+	}
+
+	t.Setenv("MULTUS_ADMISSION_CONTROLLER_IMAGE", "quay.io/openshift/multus-admission-controller:4.16\n")
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.Image).To(Equal("quay.io/openshift/multus-admission-controller:4.16"))
+
+	t.Setenv("KUBE_RBAC_PROXY_IMAGE", "quay.io/")
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid KUBE_RBAC_PROXY_IMAGE")))
+}
+
+// TestRenderMultusAdmissionControllerKubeRBACProxyFallbackImage tests that KUBE_RBAC_PROXY_IMAGE
+// takes precedence over the configured fallback image, and the fallback over the build default
+func TestRenderMultusAdmissionControllerKubeRBACProxyFallbackImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderProxyImage := func(fallback string) (string, error) {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.KubeRBACProxyFallbackImage = fallback
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		if err != nil {
+			return "", err
+		}
+		return getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "kube-rbac-proxy").Image, nil
+	}
+
+	t.Setenv("KUBE_RBAC_PROXY_IMAGE", "")
+	image, err := renderProxyImage("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(image).To(BeEmpty())
+
+	defaultImage := defaultKubeRBACProxyImage
+	t.Cleanup(func() { defaultKubeRBACProxyImage = defaultImage })
+	defaultKubeRBACProxyImage = "quay.io/openshift/kube-rbac-proxy:dev"
+	image, err = renderProxyImage("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(image).To(Equal("quay.io/openshift/kube-rbac-proxy:dev"))
+
+	image, err = renderProxyImage("Quay.io/openshift/kube-rbac-proxy:fallback")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(image).To(Equal("quay.io/openshift/kube-rbac-proxy:fallback"))
+
+	t.Setenv("KUBE_RBAC_PROXY_IMAGE", "quay.io/openshift/kube-rbac-proxy:4.16")
+	image, err = renderProxyImage("quay.io/openshift/kube-rbac-proxy:fallback")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(image).To(Equal("quay.io/openshift/kube-rbac-proxy:4.16"))
+
+	_, err = renderProxyImage("quay.io/")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid kube-rbac-proxy-fallback-image")))
+}
+
+// TestRenderMultusAdmissionControllerMeshInjection tests that service mesh sidecar injection is disabled by default
+func TestRenderMultusAdmissionControllerMeshInjection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	annotations := getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Annotations
+	g.Expect(annotations).To(HaveKeyWithValue("sidecar.istio.io/inject", "false"))
+	g.Expect(annotations).To(HaveKeyWithValue("linkerd.io/inject", "disabled"))
+
+	meshInjection := true
+	bootstrapResult.MultusAdmissionController.MeshInjection = &meshInjection
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	annotations = getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Annotations
+	g.Expect(annotations).NotTo(HaveKey("sidecar.istio.io/inject"))
+	g.Expect(annotations).NotTo(HaveKey("linkerd.io/inject"))
+}
+
+// TestRenderMultusAdmissionControllerServingCertSecret tests an externally provisioned webhook serving certificate
+func TestRenderMultusAdmissionControllerServingCertSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getWebhook := func(objs []*uns.Unstructured) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				return webhook
+			}
+		}
+		g.Expect("ValidatingWebhookConfiguration").To(BeEmpty(), "not rendered")
+		return nil
+	}
+	getSecretName := func(objs []*uns.Unstructured) string {
+		for _, volume := range getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.Volumes {
+			if volume.Name == "webhook-certs" {
+				return volume.Secret.SecretName
+			}
+		}
+		return ""
+	}
+	secret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-multus", Name: "external-serving-cert"},
+			Data:       data,
+		}
+	}
+
+	// the service CA secret by default
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSecretName(objs)).To(Equal("multus-admission-controller-secret"))
+	webhook := getWebhook(objs)
+	g.Expect(webhook.Annotations).To(HaveKeyWithValue("service.beta.openshift.io/inject-cabundle", "true"))
+	g.Expect(webhook.Webhooks[0].ClientConfig.CABundle).To(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.ServingCertSecret = "external-serving-cert"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError("serving-cert-secret openshift-multus/external-serving-cert does not exist"))
+
+	client := cnofake.NewFakeClient(secret(map[string][]byte{"tls.crt": []byte("cert")}))
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError("serving-cert-secret openshift-multus/external-serving-cert is missing the tls.key key"))
+
+	client = cnofake.NewFakeClient(secret(map[string][]byte{"tls.key": []byte("key")}))
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError("serving-cert-secret openshift-multus/external-serving-cert is missing the tls.crt key"))
+
+	client = cnofake.NewFakeClient(secret(map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")}))
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSecretName(objs)).To(Equal("external-serving-cert"))
+	webhook = getWebhook(objs)
+	g.Expect(webhook.Annotations).NotTo(HaveKey("service.beta.openshift.io/inject-cabundle"))
+	g.Expect(webhook.Webhooks[0].ClientConfig.CABundle).NotTo(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.ServingCertSecret = "Invalid_Name"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid serving-cert-secret")))
+}
+
+// TestRenderMultusAdmissionControllerExtraWebhookRules tests merging extra rules into the webhook configuration
+func TestRenderMultusAdmissionControllerExtraWebhookRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getRules := func(objs []*uns.Unstructured) []admissionregistrationv1.RuleWithOperations {
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				g.Expect(webhook.Webhooks).To(HaveLen(1))
+				return webhook.Webhooks[0].Rules
+			}
+		}
+		return nil
+	}
+	nadRule := admissionregistrationv1.RuleWithOperations{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"k8s.cni.cncf.io"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"network-attachment-definitions"},
+		},
+	}
+	extraRule := admissionregistrationv1.RuleWithOperations{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"k8s.cni.cncf.io"},
+			APIVersions: []string{"v1alpha1"},
+			Resources:   []string{"multi-networkpolicies"},
+		},
+	}
+
+	client := cnofake.NewFakeClient()
+	client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "k8s.cni.cncf.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "multi-networkpolicies"}},
+	}}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getRules(objs)).To(Equal([]admissionregistrationv1.RuleWithOperations{nadRule}))
+
+	bootstrapResult.MultusAdmissionController.ExtraWebhookRules = []admissionregistrationv1.RuleWithOperations{extraRule}
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getRules(objs)).To(Equal([]admissionregistrationv1.RuleWithOperations{nadRule, extraRule}))
+
+	// not served
+	unknownRule := *extraRule.DeepCopy()
+	unknownRule.Resources = []string{"foos"}
+	bootstrapResult.MultusAdmissionController.ExtraWebhookRules = []admissionregistrationv1.RuleWithOperations{unknownRule}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(`invalid extra-webhook-rules: resource "foos" of k8s.cni.cncf.io/v1alpha1 is not served by the cluster`))
+	unknownRule = *extraRule.DeepCopy()
+	unknownRule.APIGroups = []string{"example.com"}
+	bootstrapResult.MultusAdmissionController.ExtraWebhookRules = []admissionregistrationv1.RuleWithOperations{unknownRule}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("of example.com/v1alpha1 is not served by the cluster")))
+
+	// wildcards can't be checked against discovery
+	wildcardRule := *extraRule.DeepCopy()
+	wildcardRule.Resources = []string{"*"}
+	bootstrapResult.MultusAdmissionController.ExtraWebhookRules = []admissionregistrationv1.RuleWithOperations{wildcardRule}
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(`invalid extra-webhook-rules rule 0: resources "*" must not be a wildcard`))
+}
+
+// TestRenderMultusAdmissionControllerNamespaceSelectorStrategy tests the webhook namespaceSelector
+// of both strategies, and the labeling of the ignored namespaces with the Labels one
+func TestRenderMultusAdmissionControllerNamespaceSelectorStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ignoredNamespaces = "openshift-test,openshift-etcd"
+	ignoredNamespacesUpdated = time.Now()
+	t.Cleanup(func() { ignoredNamespaces, ignoredNamespacesUpdated = "", time.Time{} })
+
+	getNamespaceSelector := func(objs []*uns.Unstructured) *metav1.LabelSelector {
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				g.Expect(webhook.Webhooks).To(HaveLen(1))
+				return webhook.Webhooks[0].NamespaceSelector
+			}
+		}
+		return nil
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getNamespaceSelector(objs)).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.NamespaceSelectorStrategy = "Names"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getNamespaceSelector(objs)).To(Equal(&metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "kubernetes.io/metadata.name",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"openshift-console", "openshift-etcd", "openshift-ingress-canary", "openshift-test"},
+		}},
+	}))
+
+	// openshift-console and openshift-ingress-canary don't exist, openshift-old isn't ignored anymore
+	client := cnofake.NewFakeClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-test"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-etcd"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "openshift-old",
+			Labels: map[string]string{multusAdmissionControllerIgnoredLabel: "true"},
+		}},
+	)
+	bootstrapResult.MultusAdmissionController.NamespaceSelectorStrategy = "Labels"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getNamespaceSelector(objs)).To(Equal(&metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: multusAdmissionControllerIgnoredLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+			{Key: "multus.openshift.io/admission", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"ignore"}},
+		},
+	}))
+	getLabeled := func() []string {
+		labeled, err := client.Default().Kubernetes().CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+			LabelSelector: multusAdmissionControllerIgnoredLabel,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		names := []string{}
+		for _, ns := range labeled.Items {
+			names = append(names, ns.Name)
+		}
+		return names
+	}
+	// the namespaces are only labeled once the objects are applied
+	g.Expect(getLabeled()).To(ConsistOf("openshift-old"))
+	g.Expect(LabelMultusAdmissionControllerIgnoredNamespaces(context.TODO(), client, &bootstrapResult.MultusAdmissionController)).To(Succeed())
+	g.Expect(getLabeled()).To(ConsistOf("openshift-test", "openshift-etcd"))
+
+	// switching to another strategy removes the labels
+	bootstrapResult.MultusAdmissionController.NamespaceSelectorStrategy = "Names"
+	g.Expect(LabelMultusAdmissionControllerIgnoredNamespaces(context.TODO(), client, &bootstrapResult.MultusAdmissionController)).To(Succeed())
+	g.Expect(getLabeled()).To(BeEmpty())
+
+	bootstrapResult.MultusAdmissionController.NamespaceSelectorStrategy = "Annotations"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid namespace-selector-strategy")))
+}
+
+// TestRenderMultusAdmissionControllerWebhookObjectSelector tests the webhook objectSelector
+func TestRenderMultusAdmissionControllerWebhookObjectSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getObjectSelector := func(objs []*uns.Unstructured) *metav1.LabelSelector {
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				g.Expect(webhook.Webhooks).To(HaveLen(1))
+				return webhook.Webhooks[0].ObjectSelector
+			}
+		}
+		return nil
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getObjectSelector(objs)).To(BeNil())
+
+	bootstrapResult.MultusAdmissionController.WebhookObjectSelector = "validate=true,tier notin (test)"
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getObjectSelector(objs)).To(Equal(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"validate": "true"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "tier",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"test"},
+		}},
+	}))
+
+	bootstrapResult.MultusAdmissionController.WebhookObjectSelector = "tier in (test"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid webhook-object-selector")))
+}
+
+// TestRenderMultusAdmissionControllerReconcileInterval tests the reconcile interval annotation
+func TestRenderMultusAdmissionControllerReconcileInterval(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		g.Expect(obj.GetAnnotations()).NotTo(HaveKey(names.ReconcileIntervalAnnotation))
+	}
+
+	bootstrapResult.MultusAdmissionController.ReconcileInterval = durationPtr(15 * time.Minute)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		g.Expect(obj.GetAnnotations()).To(HaveKeyWithValue(names.ReconcileIntervalAnnotation, "15m0s"))
+	}
+
+	for _, interval := range []time.Duration{time.Minute, 2 * time.Hour} {
+		bootstrapResult.MultusAdmissionController.ReconcileInterval = durationPtr(interval)
+		_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).To(MatchError(ContainSubstring("invalid reconcile-interval")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerHostAliases tests the HyperShift pod host aliases
+func TestRenderMultusAdmissionControllerHostAliases(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.HostAliases).To(BeEmpty())
+
+	hostAliases := []corev1.HostAlias{
+		{IP: "10.0.0.10", Hostnames: []string{"api.management.example.com"}},
+		{IP: "fd00::10", Hostnames: []string{"api-int.management.example.com", "api-int"}},
+	}
+	bootstrapResult.MultusAdmissionController.HostAliases = hostAliases
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.HostAliases).To(Equal(hostAliases))
+
+	// ignored without HyperShift
+	nonHyperShift := fakeBootstrapResult()
+	nonHyperShift.MultusAdmissionController.HostAliases = hostAliases
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, nonHyperShift, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec.HostAliases).To(BeEmpty())
+
+	for _, invalid := range []corev1.HostAlias{
+		{IP: "api.management.example.com", Hostnames: []string{"api"}},
+		{IP: "10.0.0.10"},
+		{IP: "10.0.0.10", Hostnames: []string{"API_Management"}},
+	} {
+		bootstrapResult.MultusAdmissionController.HostAliases = []corev1.HostAlias{invalid}
+		_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).To(MatchError(ContainSubstring("invalid host-aliases")))
+	}
+}
+
+// TestRenderMultusAdmissionControllerMetricsService tests serving the metrics through a dedicated
+// Service, selected by the ServiceMonitor instead of the webhook Service
+func TestRenderMultusAdmissionControllerMetricsService(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getServiceMonitorSelector := func(objs []*uns.Unstructured) map[string]string {
+		for _, obj := range objs {
+			if obj.GetKind() == "ServiceMonitor" {
+				selector, _, err := uns.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+				g.Expect(err).NotTo(HaveOccurred())
+				return selector
+			}
+		}
+		t.Fatal("no ServiceMonitor rendered")
+		return nil
+	}
+	getServices := func(objs []*uns.Unstructured) []*corev1.Service {
+		var services []*corev1.Service
+		for _, obj := range objs {
+			if obj.GetKind() == "Service" {
+				service := &corev1.Service{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, service)).To(Succeed())
+				services = append(services, service)
+			}
+		}
+		return services
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getServices(objs)).To(HaveLen(1))
+	g.Expect(getMultusAdmissionControllerService(g, objs).Spec.Ports).To(ContainElement(HaveField("Name", "metrics")))
+
+	bootstrapResult.MultusAdmissionController.MetricsService = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getMultusAdmissionControllerService(g, objs).Spec.Ports).To(ConsistOf(HaveField("Name", "webhook")))
+
+	// the ServiceMonitor selects the metrics Service only
+	selector := labels.SelectorFromSet(getServiceMonitorSelector(objs))
+	var selected []string
+	for _, service := range getServices(objs) {
+		if selector.Matches(labels.Set(service.Labels)) {
+			selected = append(selected, service.Name)
+			g.Expect(service.Spec.Selector).To(Equal(map[string]string{"app": "multus-admission-controller"}))
+			g.Expect(service.Spec.Ports).To(ConsistOf(And(
+				HaveField("Name", "metrics"),
+				HaveField("Port", int32(8443)),
+				HaveField("TargetPort", intstr.FromString("https")),
+			)))
+		}
+	}
+	g.Expect(selected).To(ConsistOf("multus-admission-controller-metrics"))
+}
+
+// TestRenderMultusAdmissionControllerDisableServiceMonitor tests suppressing the ServiceMonitor
+func TestRenderMultusAdmissionControllerDisableServiceMonitor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	hasServiceMonitor := func(objs []*uns.Unstructured) bool {
+		for _, obj := range objs {
+			if obj.GetKind() == "ServiceMonitor" {
+				return true
+			}
+		}
+		return false
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hasServiceMonitor(objs)).To(BeTrue())
+
+	bootstrapResult.MultusAdmissionController.DisableServiceMonitor = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hasServiceMonitor(objs)).To(BeFalse())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("Role", "openshift-multus", "prometheus-k8s")))
+
+	t.Setenv("RHOBS_MONITORING", "1")
+	hyperShiftResult, client := fakeMultusAdmissionControllerHyperShift()
+	hyperShiftResult.MultusAdmissionController.DisableServiceMonitor = true
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, hyperShiftResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hasServiceMonitor(objs)).To(BeFalse())
+}
+
+// TestRenderMultusAdmissionControllerCertReloadStrategy tests how the controller picks up a rotated serving certificate
+func TestRenderMultusAdmissionControllerCertReloadStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderDeployment := func(strategy string) *appsv1.Deployment {
+		bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+		bootstrapResult.MultusAdmissionController.CertReloadStrategy = strategy
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		return getMultusAdmissionControllerDeployment(g, objs)
+	}
+
+	// pod restart by default
+	for _, strategy := range []string{"", "PodRestart"} {
+		deployment := renderDeployment(strategy)
+		g.Expect(deployment.Spec.Template.Annotations).To(HaveKey("network.operator.openshift.io/service-ca-hash"))
+		container := getContainer(g, &deployment.Spec.Template.Spec, "multus-admission-controller")
+		g.Expect(strings.Join(container.Command, " ")).NotTo(ContainSubstring("-watch-tls-certs"))
+	}
+
+	deployment := renderDeployment("FileWatch")
+	g.Expect(deployment.Spec.Template.Annotations).NotTo(HaveKey("network.operator.openshift.io/service-ca-hash"))
+	container := getContainer(g, &deployment.Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(strings.Join(container.Command, " ")).To(ContainSubstring("-watch-tls-certs=true"))
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	bootstrapResult.MultusAdmissionController.CertReloadStrategy = "SIGHUP"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid cert-reload-strategy")))
+}
+
+// TestRenderMultusAdmissionControllerImagePullPolicy tests the pull policy of the containers
+func TestRenderMultusAdmissionControllerImagePullPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, tc := range []struct {
+		policy   corev1.PullPolicy
+		expected corev1.PullPolicy
+	}{
+		{"", corev1.PullIfNotPresent},
+		{corev1.PullAlways, corev1.PullAlways},
+		{corev1.PullNever, corev1.PullNever},
+	} {
+		bootstrapResult := fakeBootstrapResult()
+		bootstrapResult.MultusAdmissionController.ImagePullPolicy = tc.policy
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		podSpec := &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec
+		for _, name := range []string{"multus-admission-controller", "kube-rbac-proxy"} {
+			g.Expect(getContainer(g, podSpec, name).ImagePullPolicy).To(Equal(tc.expected), "container %s", name)
+		}
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.ImagePullPolicy = "Sometimes"
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid image-pull-policy")))
+}
+
+// TestRenderMultusAdmissionControllerDuplicateObjects tests that duplicate objects are rejected before apply
+func TestRenderMultusAdmissionControllerDuplicateObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Cleanup(func() { multusAdmissionControllerRenderMutators = nil })
+
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validateMultusAdmissionControllerUniqueObjects(objs)).To(Succeed())
+
+	// the same name in another cluster is a different object
+	other := objs[0].DeepCopy()
+	other.SetAnnotations(map[string]string{names.ClusterNameAnnotation: names.ManagementClusterName})
+	g.Expect(validateMultusAdmissionControllerUniqueObjects(append(objs, other))).To(Succeed())
+
+	RegisterMultusAdmissionControllerRenderMutator(func(objs []*uns.Unstructured) ([]*uns.Unstructured, error) {
+		for _, obj := range objs {
+			if obj.GetKind() == "Service" {
+				return append(objs, obj.DeepCopy()), nil
+			}
+		}
+		return objs, nil
+	})
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("rendered duplicate multus admission controller objects: (/v1, Kind=Service) openshift-multus/multus-admission-controller")))
+}
+
+// TestRenderMultusAdmissionControllerPaused tests scaling a paused admission controller to zero
+func TestRenderMultusAdmissionControllerPaused(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getWebhook := func(objs []*uns.Unstructured) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		var obj *uns.Unstructured
+		for _, o := range objs {
+			if o.GetKind() == "ValidatingWebhookConfiguration" {
+				obj = o
+			}
+		}
+		g.Expect(obj).NotTo(BeNil())
+		webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+		return webhook
+	}
+
+	bootstrapResult := fakeBootstrapResult()
+	unpaused, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*getMultusAdmissionControllerDeployment(g, unpaused).Spec.Replicas).To(BeNumerically(">", 0))
+	g.Expect(getWebhook(unpaused).Webhooks[0].FailurePolicy).To(HaveValue(Equal(admissionregistrationv1.Fail)))
+
+	bootstrapResult.MultusAdmissionController.Paused = true
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(HaveLen(len(unpaused)))
+	g.Expect(*getMultusAdmissionControllerDeployment(g, objs).Spec.Replicas).To(BeEquivalentTo(0))
+	g.Expect(getWebhook(objs).Webhooks[0].FailurePolicy).To(HaveValue(Equal(admissionregistrationv1.Ignore)))
+
+	bootstrapResult.MultusAdmissionController.PausedFailurePolicy = admissionregistrationv1.Fail
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getWebhook(objs).Webhooks[0].FailurePolicy).To(HaveValue(Equal(admissionregistrationv1.Fail)))
+
+	bootstrapResult.MultusAdmissionController.PausedFailurePolicy = "Retry"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid paused-failure-policy")))
+}
+
+// TestEffectiveMultusAdmissionControllerFailurePolicy tests the precedence of the failurePolicy inputs
+func TestEffectiveMultusAdmissionControllerFailurePolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, tc := range []struct {
+		name      string
+		conf      bootstrap.MultusAdmissionControllerBootstrapResult
+		effective admissionregistrationv1.FailurePolicyType
+	}{
+		{"default", bootstrap.MultusAdmissionControllerBootstrapResult{}, admissionregistrationv1.Fail},
+		{"explicit", bootstrap.MultusAdmissionControllerBootstrapResult{FailurePolicy: admissionregistrationv1.Ignore}, admissionregistrationv1.Ignore},
+		{"paused failure policy without paused", bootstrap.MultusAdmissionControllerBootstrapResult{
+			PausedFailurePolicy: admissionregistrationv1.Ignore,
+		}, admissionregistrationv1.Fail},
+		{"paused", bootstrap.MultusAdmissionControllerBootstrapResult{Paused: true}, admissionregistrationv1.Ignore},
+		{"paused with explicit", bootstrap.MultusAdmissionControllerBootstrapResult{
+			Paused:        true,
+			FailurePolicy: admissionregistrationv1.Fail,
+		}, admissionregistrationv1.Ignore},
+		{"paused with paused failure policy", bootstrap.MultusAdmissionControllerBootstrapResult{
+			Paused:              true,
+			PausedFailurePolicy: admissionregistrationv1.Fail,
+		}, admissionregistrationv1.Fail},
+		{"paused with both", bootstrap.MultusAdmissionControllerBootstrapResult{
+			Paused:              true,
+			FailurePolicy:       admissionregistrationv1.Ignore,
+			PausedFailurePolicy: admissionregistrationv1.Fail,
+		}, admissionregistrationv1.Fail},
+	} {
+		g.Expect(EffectiveMultusAdmissionControllerFailurePolicy(&tc.conf)).To(Equal(tc.effective), tc.name)
+	}
+
+	// the render uses the same policy
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.FailurePolicy = admissionregistrationv1.Ignore
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		if obj.GetKind() == "ValidatingWebhookConfiguration" {
+			webhooks, _, err := uns.NestedSlice(obj.Object, "webhooks")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(webhooks[0]).To(HaveKeyWithValue("failurePolicy", "Ignore"))
+		}
+	}
+
+	bootstrapResult.MultusAdmissionController.FailurePolicy = "Retry"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid failure-policy")))
+}
+
+// TestValidateMultusAdmissionControllerWebhookServices tests cross-checking the webhook Service reference with the rendered Services
+func TestValidateMultusAdmissionControllerWebhookServices(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	renderObjs := func() []*uns.Unstructured {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), cnofake.NewFakeClient(), FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		return objs
+	}
+	setServiceRef := func(objs []*uns.Unstructured, field string, value interface{}) {
+		for _, obj := range objs {
+			if obj.GetKind() != "ValidatingWebhookConfiguration" {
+				continue
+			}
+			webhooks, _, _ := uns.NestedSlice(obj.Object, "webhooks")
+			g.Expect(uns.SetNestedField(webhooks[0].(map[string]interface{}), value, "clientConfig", "service", field)).To(Succeed())
+			g.Expect(uns.SetNestedSlice(obj.Object, webhooks, "webhooks")).To(Succeed())
+		}
+	}
+
+	g.Expect(validateMultusAdmissionControllerWebhookServices(renderObjs())).To(Succeed())
+
+	objs := renderObjs()
+	setServiceRef(objs, "port", int64(443))
+	g.Expect(validateMultusAdmissionControllerWebhookServices(objs)).To(Succeed())
+
+	objs = renderObjs()
+	setServiceRef(objs, "name", "multus-admission-controller-typo")
+	g.Expect(validateMultusAdmissionControllerWebhookServices(objs)).To(MatchError(ContainSubstring("references Service openshift-multus/multus-admission-controller-typo, which is not rendered")))
+
+	objs = renderObjs()
+	setServiceRef(objs, "namespace", "openshift-multus-typo")
+	g.Expect(validateMultusAdmissionControllerWebhookServices(objs)).To(MatchError(ContainSubstring("which is not rendered")))
+
+	objs = renderObjs()
+	setServiceRef(objs, "port", int64(6443))
+	g.Expect(validateMultusAdmissionControllerWebhookServices(objs)).To(MatchError(ContainSubstring("references port 6443 of Service openshift-multus/multus-admission-controller")))
+}
+
+// TestRenderMultusAdmissionControllerTemplateOutput tests wrapping the objects in an OpenShift Template
+func TestRenderMultusAdmissionControllerTemplateOutput(t *testing.T) {
+	g := NewGomegaWithT(t)
+	t.Setenv("MULTUS_ADMISSION_CONTROLLER_IMAGE", "quay.io/openshift/multus-admission-controller:test")
+
+	bootstrapResult := fakeBootstrapResult()
+	raw, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapResult.MultusAdmissionController.OutputFormat = "Template"
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(HaveLen(1))
+	g.Expect(objs[0]).To(HaveKubernetesID("Template", "openshift-network-operator", "multus-admission-controller"))
+
+	template := &templatev1.Template{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objs[0].Object, template)).To(Succeed())
+	g.Expect(template.Objects).To(HaveLen(len(raw)))
+	parameters := map[string]string{}
+	for _, parameter := range template.Parameters {
+		g.Expect(parameter.Required).To(BeTrue())
+		parameters[parameter.Name] = parameter.Value
+	}
+	g.Expect(parameters).To(Equal(map[string]string{
+		"NAMESPACE": "openshift-multus",
+		"IMAGE":     "quay.io/openshift/multus-admission-controller:test",
+		"REPLICAS":  "2",
+	}))
+
+	var deployment, webhook map[string]interface{}
+	for _, object := range template.Objects {
+		obj := map[string]interface{}{}
+		g.Expect(json.Unmarshal(object.Raw, &obj)).To(Succeed())
+		o := &uns.Unstructured{Object: obj}
+		if o.GetKind() != "Namespace" {
+			g.Expect(o.GetNamespace()).To(BeElementOf("", "${NAMESPACE}"))
+		}
+		switch o.GetKind() {
+		case "Namespace":
+			g.Expect(o.GetName()).To(Equal("${NAMESPACE}"))
+		case "Deployment":
+			deployment = obj
+		case "ValidatingWebhookConfiguration":
+			webhook = obj
+		}
+	}
+	g.Expect(deployment).NotTo(BeNil())
+	g.Expect(deployment["spec"].(map[string]interface{})["replicas"]).To(Equal("${{REPLICAS}}"))
+	containers, _, _ := uns.NestedSlice(deployment, "spec", "template", "spec", "containers")
+	images := []interface{}{}
+	for _, container := range containers {
+		images = append(images, container.(map[string]interface{})["image"])
+	}
+	g.Expect(images).To(ContainElement("${IMAGE}"))
+	webhooks, _, _ := uns.NestedSlice(webhook, "webhooks")
+	namespace, _, _ := uns.NestedString(webhooks[0].(map[string]interface{}), "clientConfig", "service", "namespace")
+	g.Expect(namespace).To(Equal("${NAMESPACE}"))
+
+	hyperShiftResult, client := fakeMultusAdmissionControllerHyperShift()
+	hyperShiftResult.MultusAdmissionController.OutputFormat = "Template"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, hyperShiftResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("not supported with HyperShift")))
+
+	bootstrapResult.MultusAdmissionController.OutputFormat = "Helm"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid output-format")))
+}
+
+// TestRenderMultusAdmissionControllerStartupProbe tests that the startup probe covers a slow first start
+func TestRenderMultusAdmissionControllerStartupProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	intPtr := func(i int) *int { return &i }
+
+	bootstrapResult := fakeBootstrapResult()
+	bootstrapResult.MultusAdmissionController.HealthProbes = true
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container := getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+
+	startup := container.StartupProbe
+	g.Expect(startup).NotTo(BeNil())
+	g.Expect(startup.HTTPGet).NotTo(BeNil())
+	g.Expect(startup.HTTPGet.Path).To(Equal(container.ReadinessProbe.HTTPGet.Path))
+	g.Expect(startup.PeriodSeconds).To(Equal(int32(10)))
+	g.Expect(startup.FailureThreshold).To(Equal(int32(30)))
+	// the kubelet holds back the liveness probe until the startup probe succeeds, so a start
+	// takes up to the whole startup budget, well beyond what liveness alone would tolerate
+	liveness := container.LivenessProbe
+	g.Expect(startup.PeriodSeconds * startup.FailureThreshold).To(BeNumerically(">",
+		liveness.InitialDelaySeconds+liveness.PeriodSeconds*liveness.FailureThreshold))
+
+	bootstrapResult.MultusAdmissionController.StartupProbePeriodSeconds = intPtr(15)
+	bootstrapResult.MultusAdmissionController.StartupProbeFailureThreshold = intPtr(60)
+	bootstrapResult.MultusAdmissionController.ProgressDeadlineSeconds = intPtr(1200)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	container = getContainer(g, &getMultusAdmissionControllerDeployment(g, objs).Spec.Template.Spec, "multus-admission-controller")
+	g.Expect(container.StartupProbe.PeriodSeconds).To(Equal(int32(15)))
+	g.Expect(container.StartupProbe.FailureThreshold).To(Equal(int32(60)))
+
+	bootstrapResult.MultusAdmissionController.StartupProbeFailureThreshold = intPtr(0)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid startup-probe-failure-threshold")))
+
+	bootstrapResult.MultusAdmissionController.StartupProbeFailureThreshold = nil
+	bootstrapResult.MultusAdmissionController.StartupProbePeriodSeconds = intPtr(2)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("must not be greater than startup-probe-period-seconds")))
+}
+
+// renderDataKeysSet returns the render data keys that the given Go source file sets, as
+// data.Data["Key"] = ... assignments.
+func renderDataKeysSet(g *WithT, path string) map[string]bool {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	keys := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for _, lhs := range assign.Lhs {
+			index, ok := lhs.(*ast.IndexExpr)
+			if !ok {
+				continue
+			}
+			selector, ok := index.X.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "Data" {
+				continue
+			}
+			if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != "data" {
+				continue
+			}
+			if key, ok := index.Index.(*ast.BasicLit); ok && key.Kind == token.STRING {
+				value, err := strconv.Unquote(key.Value)
+				g.Expect(err).NotTo(HaveOccurred())
+				keys[value] = true
+			}
+		}
+		return true
+	})
+	return keys
+}
+
+// TestMultusAdmissionControllerTemplateDataKeys tests that every render data key the admission
+// controller templates reference is set by the render code, including in branches the other
+// tests don't render
+func TestMultusAdmissionControllerTemplateDataKeys(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	referenced, err := render.TemplateDataKeys(multusAdmissionControllerTemplatePath(manifestDir), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(referenced).NotTo(BeEmpty())
+
+	set := renderDataKeysSet(g, "multus_admission_controller.go")
+	for key, files := range referenced {
+		g.Expect(set).To(HaveKey(key), "render data key %q referenced by %v is never set", key, files)
+	}
+}
+
+// TestRenderMultusAdmissionControllerProgressDeadline tests the Deployment progressDeadlineSeconds
+func TestRenderMultusAdmissionControllerProgressDeadline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	intPtr := func(i int) *int { return &i }
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*getMultusAdmissionControllerDeployment(g, objs).Spec.ProgressDeadlineSeconds).To(Equal(int32(600)))
+
+	bootstrapResult.MultusAdmissionController.ProgressDeadlineSeconds = intPtr(301)
+	objs, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*getMultusAdmissionControllerDeployment(g, objs).Spec.ProgressDeadlineSeconds).To(Equal(int32(301)))
+
+	// the startup budget only bounds the deadline with the probes on
+	bootstrapResult.MultusAdmissionController.ProgressDeadlineSeconds = intPtr(300)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the default 300 seconds startup budget
+	bootstrapResult.MultusAdmissionController.HealthProbes = true
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid progress-deadline-seconds 300: must be greater than the 300 seconds startup probe budget")))
+
+	// a larger startup budget needs a larger deadline than the default
+	bootstrapResult.MultusAdmissionController.ProgressDeadlineSeconds = nil
+	bootstrapResult.MultusAdmissionController.StartupProbeFailureThreshold = intPtr(60)
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid progress-deadline-seconds 600")))
+	g.Expect(multusAdmissionControllerStartupBudgetSeconds(&bootstrapResult.MultusAdmissionController)).To(Equal(600))
+}
+
+// TestRenderMultusAdmissionControllerAdmissionReviewVersions tests the webhook admissionReviewVersions,
+// derived from the API server version or overridden
+func TestRenderMultusAdmissionControllerAdmissionReviewVersions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		version      *version.Info
+		discoveryErr bool
+		override     []string
+		expected     []string
+		err          string
+	}{
+		{
+			name:     "unknown version",
+			expected: []string{"v1"},
+		},
+		{
+			name:     "current version",
+			version:  &version.Info{Major: "1", Minor: "29"},
+			expected: []string{"v1"},
+		},
+		{
+			name:     "old version",
+			version:  &version.Info{Major: "1", Minor: "15+"},
+			expected: []string{"v1beta1"},
+		},
+		{
+			name:     "override",
+			version:  &version.Info{Major: "1", Minor: "29"},
+			override: []string{"v1", "v1beta1"},
+			expected: []string{"v1", "v1beta1"},
+		},
+		{
+			name:     "override partly supported",
+			version:  &version.Info{Major: "1", Minor: "15"},
+			override: []string{"v1", "v1beta1"},
+			expected: []string{"v1", "v1beta1"},
+		},
+		{
+			name:     "override not supported",
+			version:  &version.Info{Major: "1", Minor: "15"},
+			override: []string{"v1"},
+			err:      "invalid admission-review-versions [v1]: the API server 1.15 only supports [v1beta1]",
+		},
+		{
+			name:     "invalid override",
+			override: []string{"v2"},
+			err:      `invalid admission-review-versions "v2"`,
+		},
+		{
+			name:         "discovery failure",
+			discoveryErr: true,
+			expected:     []string{"v1"},
+		},
+		{
+			name:         "discovery failure with override",
+			discoveryErr: true,
+			override:     []string{"v1beta1"},
+			expected:     []string{"v1beta1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			multusAdmissionControllerServerVersion = nil
+			t.Cleanup(func() { multusAdmissionControllerServerVersion = nil })
+
+			client := cnofake.NewFakeClient()
+			client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = tc.version
+			if tc.discoveryErr {
+				client.Default().Kubernetes().(*kubefake.Clientset).PrependReactor("get", "version",
+					func(action clienttesting.Action) (bool, runtime.Object, error) {
+						return true, nil, fmt.Errorf("api unavailable")
+					})
+			}
+			bootstrapResult := fakeBootstrapResult()
+			bootstrapResult.MultusAdmissionController.AdmissionReviewVersions = tc.override
+			objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, bootstrapResult, client, FeatureGateSet{})
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			for _, obj := range objs {
+				if obj.GetKind() != "ValidatingWebhookConfiguration" {
+					continue
+				}
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				g.Expect(webhook.Webhooks[0].AdmissionReviewVersions).To(Equal(tc.expected))
+			}
+		})
+	}
+}
+
+// TestRenderMultusAdmissionControllerServerVersionCache tests that the API server version is only
+// discovered once
+func TestRenderMultusAdmissionControllerServerVersionCache(t *testing.T) {
+	g := NewGomegaWithT(t)
+	multusAdmissionControllerServerVersion = nil
+	t.Cleanup(func() { multusAdmissionControllerServerVersion = nil })
+
+	client := cnofake.NewFakeClient()
+	discovery := client.Default().Kubernetes().Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{Major: "1", Minor: "15"}
+	webhookVersions := func() []string {
+		objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, false, fakeBootstrapResult(), client, FeatureGateSet{})
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, obj := range objs {
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+				g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+				return webhook.Webhooks[0].AdmissionReviewVersions
+			}
+		}
+		return nil
+	}
+
+	g.Expect(webhookVersions()).To(Equal([]string{"v1beta1"}))
+	discovery.FakedServerVersion = &version.Info{Major: "1", Minor: "29"}
+	g.Expect(webhookVersions()).To(Equal([]string{"v1beta1"}))
+}
+
+// TestRenderMultusAdmissionControllerServiceCAKey tests reading the management cluster service CA
+// from a differently named key
+func TestRenderMultusAdmissionControllerServiceCAKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bootstrapResult, _ := fakeMultusAdmissionControllerHyperShift()
+	client := cnofake.NewFakeClientWithClusters(map[string][]crclient.Object{
+		names.ManagementClusterName: {
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "clusters-test"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: "clusters-test"},
+				Data:       map[string]string{"ca-bundle.crt": "test-ca", "README": "not a CA"},
+			},
+		},
+	})
+
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring(`missing "service-ca.crt" key, selected by service-ca-key; available keys: [README ca-bundle.crt]`)))
+
+	bootstrapResult.MultusAdmissionController.ServiceCAKey = "ca-bundle.crt"
+	objs, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		switch obj.GetKind() {
+		case "ValidatingWebhookConfiguration":
+			webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, webhook)).To(Succeed())
+			g.Expect(string(webhook.Webhooks[0].ClientConfig.CABundle)).To(Equal("test-ca"))
+		case "ServiceMonitor":
+			endpoints, _, _ := uns.NestedSlice(obj.Object, "spec", "endpoints")
+			key, _, _ := uns.NestedString(endpoints[0].(map[string]interface{}), "tlsConfig", "ca", "configMap", "key")
+			g.Expect(key).To(Equal("ca-bundle.crt"))
+		}
+	}
+
+	bootstrapResult.MultusAdmissionController.ServiceCAKey = "ca bundle"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid service-ca-key")))
+}
+
+// spanNameRecorder is a span processor recording the names of the spans started
+type spanNameRecorder struct {
+	names []string
+}
+
+func (r *spanNameRecorder) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	r.names = append(r.names, s.Name())
+}
+func (r *spanNameRecorder) OnEnd(sdktrace.ReadOnlySpan)      {}
+func (r *spanNameRecorder) Shutdown(context.Context) error   { return nil }
+func (r *spanNameRecorder) ForceFlush(context.Context) error { return nil }
+
+// TestRenderMultusAdmissionControllerTracing tests that the render phases are traced in order
+func TestRenderMultusAdmissionControllerTracing(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	previous := otel.GetTracerProvider()
+	recorder := &spanNameRecorder{}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	bootstrapResult, client := fakeMultusAdmissionControllerHyperShift()
+	_, err := renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.names).To(Equal([]string{
+		"RenderMultusAdmissionController",
+		"DiscoverIgnoredNamespaces",
+		"DiscoverAPIServer",
+		"GetManagementNamespace",
+		"GetServiceCA",
+		"RenderDir",
+		"Validate",
+		"ValidateObjectSizes",
+	}))
+
+	// a failing phase ends the trace
+	recorder.names = nil
+	bootstrapResult.MultusAdmissionController.ServiceCAKey = "missing.crt"
+	_, err = renderMultusAdmissonControllerConfig(multusAdmissionControllerTestConfig(), manifestDir, true, bootstrapResult, client, FeatureGateSet{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(recorder.names).To(HaveLen(5))
+	g.Expect(recorder.names[len(recorder.names)-1]).To(Equal("GetServiceCA"))
+}
+
+// TestRenderMultusAdmissionControllerTemplatePath tests rendering from a fixture template directory
+func TestRenderMultusAdmissionControllerTemplatePath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	templatePath := t.TempDir()
+	fixture := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fixture
+  namespace: {{.AdmissionControllerNamespace}}
+data:
+  replicas: "{{.Replicas}}"
+`
+	g.Expect(os.WriteFile(filepath.Join(templatePath, "fixture.yaml"), []byte(fixture), 0o644)).To(Succeed())
+
+	bootstrapResult := fakeBootstrapResult()
+	objs, err := renderMultusAdmissionControllerTemplates(multusAdmissionControllerTestConfig(), templatePath, false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ConfigMap", "openshift-multus", "fixture")))
+	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("Deployment", "openshift-multus", "multus-admission-controller")))
+
+	_, err = renderMultusAdmissionControllerTemplates(multusAdmissionControllerTestConfig(), filepath.Join(templatePath, "missing"), false, bootstrapResult, cnofake.NewFakeClient(), FeatureGateSet{})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to render multus admission controller manifests")))
+}
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"sort"
 	"testing"
 
 	operv1 "github.com/openshift/api/operator/v1"
@@ -58,3 +59,27 @@ func TestRenderMultus(t *testing.T) {
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRole", "", "multus")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "multus")))
 }
+
+// TestGetInstalledCNIPlugins tests the CNI plugins reported as installed on the nodes
+func TestGetInstalledCNIPlugins(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := MultusConfig.Spec.DeepCopy()
+	plugins := getInstalledCNIPlugins(config, nil)
+	g.Expect(plugins).To(ContainElements("bridge", "macvlan", "bond", "route-override", "whereabouts", "openshift-sdn"))
+	g.Expect(plugins).NotTo(ContainElement("ovn-k8s-cni-overlay"))
+	g.Expect(sort.StringsAreSorted(plugins)).To(BeTrue())
+
+	config.DefaultNetwork.Type = operv1.NetworkTypeOVNKubernetes
+	plugins = getInstalledCNIPlugins(config, nil)
+	g.Expect(plugins).To(ContainElement("ovn-k8s-cni-overlay"))
+	g.Expect(plugins).NotTo(ContainElement("openshift-sdn"))
+
+	// third party plugins are reported as installed only when configured
+	config.DefaultNetwork.Type = "MyAwesomeThirdPartyPlugin"
+	plugins = getInstalledCNIPlugins(config, nil)
+	g.Expect(plugins).To(HaveLen(len(multusCNIPlugins)))
+	plugins = getInstalledCNIPlugins(config, []string{"my-awesome-cni", "bridge"})
+	g.Expect(plugins).To(HaveLen(len(multusCNIPlugins) + 1))
+	g.Expect(plugins).To(ContainElement("my-awesome-cni"))
+}
@@ -2,6 +2,9 @@ package render
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +14,7 @@ import (
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/openshift/cluster-network-operator/pkg/names"
 	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -97,14 +101,7 @@ func (a byFilename) Less(i, j int) bool {
 // RenderTemplate reads, renders, and attempts to parse a yaml or
 // json file representing one or more k8s api objects
 func RenderTemplate(path string, d *RenderData) ([]*unstructured.Unstructured, error) {
-	tmpl := template.New(path).Option("missingkey=error")
-	if d.Funcs != nil {
-		tmpl.Funcs(d.Funcs)
-	}
-
-	// Add universal functions
-	tmpl.Funcs(template.FuncMap{"getOr": getOr, "isSet": isSet, "iniEscapeCharacters": iniEscapeCharacters})
-	tmpl.Funcs(sprig.TxtFuncMap())
+	tmpl := newTemplate(path, d.Funcs)
 
 	source, err := os.ReadFile(path)
 	if err != nil {
@@ -141,3 +138,71 @@ func RenderTemplate(path string, d *RenderData) ([]*unstructured.Unstructured, e
 
 	return out, nil
 }
+
+// newTemplate returns a template with the given functions and the universal ones
+func newTemplate(name string, funcs template.FuncMap) *template.Template {
+	tmpl := template.New(name).Option("missingkey=error")
+	if funcs != nil {
+		tmpl.Funcs(funcs)
+	}
+
+	// Add universal functions
+	tmpl.Funcs(template.FuncMap{"getOr": getOr, "isSet": isSet, "iniEscapeCharacters": iniEscapeCharacters})
+	tmpl.Funcs(sprig.TxtFuncMap())
+	return tmpl
+}
+
+// RenderHash returns a hash of the rendered objects that only depends on their content: identical
+// renders hash the same regardless of the order of the objects, across runs and machines. The
+// content generation annotation and the fields set by the API server aren't part of the hash.
+func RenderHash(objs []*unstructured.Unstructured) string {
+	type keyed struct {
+		key  string
+		data []byte
+	}
+	entries := make([]keyed, 0, len(objs))
+	for _, obj := range objs {
+		obj = normalizeForHash(obj)
+		gvk := obj.GroupVersionKind()
+		key := strings.Join([]string{obj.GetAnnotations()[names.ClusterNameAnnotation], gvk.Group, gvk.Version, gvk.Kind,
+			obj.GetNamespace(), obj.GetName()}, "/")
+		// json sorts the map keys
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			// unstructured content is always JSON compatible, fall back on the printed form,
+			// which also sorts the map keys
+			data = []byte(fmt.Sprintf("%#v", obj.Object))
+		}
+		entries = append(entries, keyed{key: key, data: data})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].key != entries[j].key {
+			return entries[i].key < entries[j].key
+		}
+		return bytes.Compare(entries[i].data, entries[j].data) < 0
+	})
+
+	h := sha256.New()
+	for _, entry := range entries {
+		// length prefixed, so that the boundaries between objects are part of the hash
+		fmt.Fprintf(h, "%d:%s%d:", len(entry.key), entry.key, len(entry.data))
+		h.Write(entry.data)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalizeForHash returns a copy of obj without the content that doesn't come from the render
+func normalizeForHash(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	anno := obj.GetAnnotations()
+	delete(anno, names.ContentGenerationAnnotation)
+	if len(anno) == 0 {
+		anno = nil
+	}
+	obj.SetAnnotations(anno)
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	return obj
+}
@@ -1,10 +1,18 @@
 package render
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"text/template"
 
 	. "github.com/onsi/gomega"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 // TestRenderSimple tests rendering a single object with no templates
@@ -114,3 +122,151 @@ func TestRenderMultiDir(t *testing.T) {
 		g.Expect(obj.GetName()).To(Equal(strconv.Itoa(i + 1)))
 	}
 }
+
+// TestRenderHash tests that the render hash only depends on the rendered content
+func TestRenderHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newObj := func(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "ns",
+			},
+			"spec": spec,
+		}}
+		return obj
+	}
+	objs := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			newObj("ConfigMap", "a", map[string]interface{}{"x": "1", "y": int64(2), "z": []interface{}{"a", "b"}}),
+			newObj("ConfigMap", "b", map[string]interface{}{"x": "1"}),
+			newObj("Service", "a", map[string]interface{}{"port": int64(443)}),
+		}
+	}
+
+	expected := RenderHash(objs())
+	g.Expect(expected).To(HaveLen(64))
+	for i := 0; i < 10; i++ {
+		g.Expect(RenderHash(objs())).To(Equal(expected))
+	}
+
+	// regardless of the object order
+	reversed := objs()
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	g.Expect(RenderHash(reversed)).To(Equal(expected))
+
+	// rendered from templates
+	d := MakeRenderData()
+	simple, err := RenderTemplate("testdata/simple.yaml", &d)
+	g.Expect(err).NotTo(HaveOccurred())
+	simpleJSON, err := RenderTemplate("testdata/simple.json", &d)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(RenderHash(simple)).To(Equal(RenderHash(simpleJSON)))
+
+	// the content generation annotation and the server side fields are ignored
+	tagged := objs()
+	tagged[0].SetAnnotations(map[string]string{names.ContentGenerationAnnotation: "1234"})
+	tagged[1].SetResourceVersion("42")
+	tagged[2].SetUID("abcd")
+	g.Expect(RenderHash(tagged)).To(Equal(expected))
+	g.Expect(tagged[0].GetAnnotations()).To(HaveKey(names.ContentGenerationAnnotation), "the objects are not modified")
+
+	// any content change changes the hash
+	changed := objs()
+	changed[0].Object["spec"].(map[string]interface{})["x"] = "2"
+	g.Expect(RenderHash(changed)).NotTo(Equal(expected))
+	changed = objs()
+	changed[1].SetAnnotations(map[string]string{"a": "b"})
+	g.Expect(RenderHash(changed)).NotTo(Equal(expected))
+	g.Expect(RenderHash(objs()[:2])).NotTo(Equal(expected))
+	g.Expect(RenderHash(nil)).NotTo(Equal(expected))
+}
+
+// TestRenderYAML tests that the multi-document YAML only depends on the content of the objects
+func TestRenderYAML(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objs := func() []*unstructured.Unstructured {
+		d := MakeRenderData()
+		objs, err := RenderTemplate("testdata/multiple.yaml", &d)
+		g.Expect(err).NotTo(HaveOccurred())
+		return objs
+	}
+
+	expected, err := RenderYAML(objs())
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := 0; i < 10; i++ {
+		out, err := RenderYAML(objs())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(out).To(Equal(expected))
+	}
+	reversed := objs()
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	out, err := RenderYAML(reversed)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal(expected))
+
+	// the documents parse back to the objects
+	parsed := []*unstructured.Unstructured{}
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(expected), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			g.Expect(err).To(Equal(io.EOF))
+			break
+		}
+		if obj.Object != nil {
+			parsed = append(parsed, obj)
+		}
+	}
+	g.Expect(parsed).To(ConsistOf(objs()))
+
+	empty, err := RenderYAML(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(empty).To(BeEmpty())
+}
+
+// TestTemplateDataKeys tests listing the render data keys the manifests reference
+func TestTemplateDataKeys(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "001.yaml"), []byte(`
+a: {{.A}}
+{{- if .B }}
+c: {{.C.Sub}}
+{{- end }}
+{{- range $i, $d := .D }}
+d: {{.Element}} {{$.E}} {{$d}}
+{{- end }}
+{{- with .F }}
+f: {{.Field}}
+{{- else }}
+g: {{.G}}
+{{- end }}
+h: {{getOr . "H" "fallback"}}
+i: {{ toJson .I }}
+j: {{ fname .J }}
+`), 0644)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(dir, "sub"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "sub", "002.yaml"), []byte("a: {{.A}}\n"), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("{{.NotAManifest}}"), 0644)).To(Succeed())
+
+	_, err := TemplateDataKeys(dir, nil)
+	g.Expect(err).To(MatchError(ContainSubstring(`function "fname" not defined`)))
+
+	keys, err := TemplateDataKeys(dir, template.FuncMap{"fname": func(s string) string { return s }})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(keys).To(HaveLen(9))
+	g.Expect(keys).To(HaveKeyWithValue("A", []string{filepath.Join(dir, "001.yaml"), filepath.Join(dir, "sub", "002.yaml")}))
+	for _, key := range []string{"B", "C", "D", "E", "F", "G", "I", "J"} {
+		g.Expect(keys).To(HaveKeyWithValue(key, []string{filepath.Join(dir, "001.yaml")}))
+	}
+}
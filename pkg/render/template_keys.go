@@ -0,0 +1,109 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateDataKeys returns the render data keys referenced by the manifests in a directory,
+// descending in to subdirectories, and for each key the manifests that reference it. A key is
+// referenced as .Key outside of range and with blocks, or as $.Key anywhere. Keys only looked up
+// with getOr or isSet are optional and aren't returned. funcs are the functions the manifests
+// use beyond the universal ones, so that they parse.
+func TemplateDataKeys(manifestDir string, funcs template.FuncMap) (map[string][]string, error) {
+	keys := map[string][]string{}
+	err := filepath.Walk(manifestDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if !(strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".json")) {
+			return nil
+		}
+
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read manifest %s", path)
+		}
+		tmpl, err := newTemplate(path, funcs).Parse(string(source))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse manifest %s as template", path)
+		}
+		fileKeys := map[string]bool{}
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				collectTemplateDataKeys(t.Tree.Root, true, fileKeys)
+			}
+		}
+		for key := range fileKeys {
+			keys[key] = append(keys[key], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing manifests")
+	}
+	for key := range keys {
+		sort.Strings(keys[key])
+	}
+	return keys, nil
+}
+
+// collectTemplateDataKeys adds the render data keys referenced by node to keys. atRoot is false
+// within range and with blocks, where dot is no longer the render data.
+func collectTemplateDataKeys(node parse.Node, atRoot bool, keys map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateDataKeys(child, atRoot, keys)
+		}
+	case *parse.ActionNode:
+		collectTemplateDataKeys(n.Pipe, atRoot, keys)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateDataKeys(cmd, atRoot, keys)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateDataKeys(arg, atRoot, keys)
+		}
+	case *parse.ChainNode:
+		collectTemplateDataKeys(n.Node, atRoot, keys)
+	case *parse.FieldNode:
+		if atRoot {
+			keys[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			keys[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		collectTemplateDataKeys(n.Pipe, atRoot, keys)
+		collectTemplateDataKeys(n.List, atRoot, keys)
+		collectTemplateDataKeys(n.ElseList, atRoot, keys)
+	case *parse.RangeNode:
+		collectTemplateDataKeys(n.Pipe, atRoot, keys)
+		collectTemplateDataKeys(n.List, false, keys)
+		collectTemplateDataKeys(n.ElseList, atRoot, keys)
+	case *parse.WithNode:
+		collectTemplateDataKeys(n.Pipe, atRoot, keys)
+		collectTemplateDataKeys(n.List, false, keys)
+		collectTemplateDataKeys(n.ElseList, atRoot, keys)
+	case *parse.TemplateNode:
+		collectTemplateDataKeys(n.Pipe, atRoot, keys)
+	}
+}
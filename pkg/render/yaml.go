@@ -0,0 +1,49 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderYAML serializes the rendered objects into a single multi-document YAML, for committing to
+// a GitOps repository. The output only depends on the content of the objects: they are sorted by
+// cluster, group, kind, namespace and name, and their fields by name, so that identical renders
+// serialize to identical bytes and a changed object only changes its own document.
+func RenderYAML(objs []*unstructured.Unstructured) ([]byte, error) {
+	type keyed struct {
+		key  string
+		data []byte
+	}
+	entries := make([]keyed, 0, len(objs))
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		key := strings.Join([]string{obj.GetAnnotations()[names.ClusterNameAnnotation], gvk.Group, gvk.Kind, gvk.Version,
+			obj.GetNamespace(), obj.GetName()}, "/")
+		// yaml goes through json, which sorts the map keys
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize (%s) %s/%s: %w", gvk, obj.GetNamespace(), obj.GetName(), err)
+		}
+		entries = append(entries, keyed{key: key, data: data})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].key != entries[j].key {
+			return entries[i].key < entries[j].key
+		}
+		return bytes.Compare(entries[i].data, entries[j].data) < 0
+	})
+
+	out := &bytes.Buffer{}
+	for _, entry := range entries {
+		out.WriteString("---\n")
+		out.Write(entry.data)
+	}
+	return out.Bytes(), nil
+}